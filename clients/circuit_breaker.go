@@ -0,0 +1,243 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"spacex-tracker/models"
+)
+
+var ErrCircuitOpen = errors.New("spacex upstream circuit breaker is open")
+
+type BreakerState int
+
+const (
+	BreakerClosed BreakerState = iota
+	BreakerOpen
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreaker opens after threshold consecutive upstream failures and
+// rejects calls until cooldown has elapsed. It then lets a single probe
+// through (half-open): success closes the circuit, failure re-opens it.
+type CircuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	state     BreakerState
+	failures  int
+	openedAt  time.Time
+	probing   bool
+	now       func() time.Time
+}
+
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		threshold: max(threshold, 1),
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// State reports the current state, moving an open breaker to half-open once
+// its cool-down has elapsed.
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.advance()
+	return b.state
+}
+
+func (b *CircuitBreaker) advance() {
+	if b.state == BreakerOpen && b.now().Sub(b.openedAt) >= b.cooldown {
+		b.state = BreakerHalfOpen
+		b.probing = false
+	}
+}
+
+// allow reports whether a call may proceed. In half-open state only one probe
+// is in flight at a time.
+func (b *CircuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.advance()
+	switch b.state {
+	case BreakerOpen:
+		return ErrCircuitOpen
+	case BreakerHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+	}
+
+	return nil
+}
+
+func (b *CircuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		// The caller gave up; this says nothing about upstream health.
+		b.probing = false
+	case isUpstreamFailure(err):
+		b.failures++
+		if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+			b.state = BreakerOpen
+			b.openedAt = b.now()
+			b.probing = false
+		}
+	default:
+		b.state = BreakerClosed
+		b.failures = 0
+		b.probing = false
+	}
+}
+
+// isUpstreamFailure reports whether err means upstream is unhealthy. A 4xx
+// answer (other than rate limiting) proves upstream is up and is not a failure.
+func isUpstreamFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var statusErr *UpstreamStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	return true
+}
+
+func guard[T any](b *CircuitBreaker, call func() (T, error)) (T, error) {
+	if err := b.allow(); err != nil {
+		var zero T
+		return zero, err
+	}
+
+	result, err := call()
+	b.record(err)
+	return result, err
+}
+
+type circuitBreakerClient struct {
+	inner   SpaceXClient
+	breaker *CircuitBreaker
+}
+
+// NewCircuitBreakerClient wraps inner so that calls fail fast with
+// ErrCircuitOpen while the breaker is open.
+func NewCircuitBreakerClient(inner SpaceXClient, breaker *CircuitBreaker) SpaceXClient {
+	return &circuitBreakerClient{
+		inner:   inner,
+		breaker: breaker,
+	}
+}
+
+func (c *circuitBreakerClient) GetNext(ctx context.Context) (*models.Launch, error) {
+	return guard(c.breaker, func() (*models.Launch, error) { return c.inner.GetNext(ctx) })
+}
+
+func (c *circuitBreakerClient) GetLatest(ctx context.Context) (*models.Launch, error) {
+	return guard(c.breaker, func() (*models.Launch, error) { return c.inner.GetLatest(ctx) })
+}
+
+func (c *circuitBreakerClient) GetUpcoming(ctx context.Context) ([]models.Launch, error) {
+	return guard(c.breaker, func() ([]models.Launch, error) { return c.inner.GetUpcoming(ctx) })
+}
+
+func (c *circuitBreakerClient) GetPast(ctx context.Context) ([]models.Launch, error) {
+	return guard(c.breaker, func() ([]models.Launch, error) { return c.inner.GetPast(ctx) })
+}
+
+func (c *circuitBreakerClient) GetLaunch(ctx context.Context, id string) (*models.Launch, error) {
+	return guard(c.breaker, func() (*models.Launch, error) { return c.inner.GetLaunch(ctx, id) })
+}
+
+func (c *circuitBreakerClient) QueryLaunches(ctx context.Context, query models.Query) (*models.Page[models.Launch], error) {
+	return guard(c.breaker, func() (*models.Page[models.Launch], error) { return c.inner.QueryLaunches(ctx, query) })
+}
+
+func (c *circuitBreakerClient) GetRocket(ctx context.Context, id string) (*models.Rocket, error) {
+	return guard(c.breaker, func() (*models.Rocket, error) { return c.inner.GetRocket(ctx, id) })
+}
+
+func (c *circuitBreakerClient) GetRockets(ctx context.Context) ([]models.Rocket, error) {
+	return guard(c.breaker, func() ([]models.Rocket, error) { return c.inner.GetRockets(ctx) })
+}
+
+func (c *circuitBreakerClient) GetLaunchpad(ctx context.Context, id string) (*models.Launchpad, error) {
+	return guard(c.breaker, func() (*models.Launchpad, error) { return c.inner.GetLaunchpad(ctx, id) })
+}
+
+func (c *circuitBreakerClient) GetLaunchpads(ctx context.Context) ([]models.Launchpad, error) {
+	return guard(c.breaker, func() ([]models.Launchpad, error) { return c.inner.GetLaunchpads(ctx) })
+}
+
+func (c *circuitBreakerClient) GetLandpad(ctx context.Context, id string) (*models.Landpad, error) {
+	return guard(c.breaker, func() (*models.Landpad, error) { return c.inner.GetLandpad(ctx, id) })
+}
+
+func (c *circuitBreakerClient) GetLandpads(ctx context.Context) ([]models.Landpad, error) {
+	return guard(c.breaker, func() ([]models.Landpad, error) { return c.inner.GetLandpads(ctx) })
+}
+
+func (c *circuitBreakerClient) GetPayload(ctx context.Context, id string) (*models.Payload, error) {
+	return guard(c.breaker, func() (*models.Payload, error) { return c.inner.GetPayload(ctx, id) })
+}
+
+func (c *circuitBreakerClient) GetPayloads(ctx context.Context) ([]models.Payload, error) {
+	return guard(c.breaker, func() ([]models.Payload, error) { return c.inner.GetPayloads(ctx) })
+}
+
+func (c *circuitBreakerClient) GetCore(ctx context.Context, id string) (*models.Core, error) {
+	return guard(c.breaker, func() (*models.Core, error) { return c.inner.GetCore(ctx, id) })
+}
+
+func (c *circuitBreakerClient) GetCores(ctx context.Context) ([]models.Core, error) {
+	return guard(c.breaker, func() ([]models.Core, error) { return c.inner.GetCores(ctx) })
+}
+
+func (c *circuitBreakerClient) GetCapsule(ctx context.Context, id string) (*models.Capsule, error) {
+	return guard(c.breaker, func() (*models.Capsule, error) { return c.inner.GetCapsule(ctx, id) })
+}
+
+func (c *circuitBreakerClient) GetCapsules(ctx context.Context) ([]models.Capsule, error) {
+	return guard(c.breaker, func() ([]models.Capsule, error) { return c.inner.GetCapsules(ctx) })
+}
+
+func (c *circuitBreakerClient) GetCrewMember(ctx context.Context, id string) (*models.CrewMember, error) {
+	return guard(c.breaker, func() (*models.CrewMember, error) { return c.inner.GetCrewMember(ctx, id) })
+}
+
+func (c *circuitBreakerClient) GetCrew(ctx context.Context) ([]models.CrewMember, error) {
+	return guard(c.breaker, func() ([]models.CrewMember, error) { return c.inner.GetCrew(ctx) })
+}
+
+func (c *circuitBreakerClient) GetShip(ctx context.Context, id string) (*models.Ship, error) {
+	return guard(c.breaker, func() (*models.Ship, error) { return c.inner.GetShip(ctx, id) })
+}
+
+func (c *circuitBreakerClient) GetShips(ctx context.Context) ([]models.Ship, error) {
+	return guard(c.breaker, func() ([]models.Ship, error) { return c.inner.GetShips(ctx) })
+}
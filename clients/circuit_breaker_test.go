@@ -0,0 +1,125 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (f *fakeClock) Now() time.Time { return f.now }
+
+func newTestBreaker(threshold int, cooldown time.Duration) (*CircuitBreaker, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	breaker := NewCircuitBreaker(threshold, cooldown)
+	breaker.now = clock.Now
+	return breaker, clock
+}
+
+func failingCall() (string, error) { return "", errors.New("upstream down") }
+
+func succeedingCall() (string, error) { return "ok", nil }
+
+func TestCircuitBreaker_OpensAfterThreshold(t *testing.T) {
+	breaker, _ := newTestBreaker(2, time.Minute)
+
+	guard(breaker, failingCall)
+	if breaker.State() != BreakerClosed {
+		t.Fatalf("expected closed after 1 failure, got %s", breaker.State())
+	}
+
+	guard(breaker, failingCall)
+	if breaker.State() != BreakerOpen {
+		t.Fatalf("expected open after 2 failures, got %s", breaker.State())
+	}
+
+	called := false
+	_, err := guard(breaker, func() (string, error) {
+		called = true
+		return "ok", nil
+	})
+
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+
+	if called {
+		t.Fatal("call should not run while the breaker is open")
+	}
+}
+
+func TestCircuitBreaker_SuccessResetsFailures(t *testing.T) {
+	breaker, _ := newTestBreaker(2, time.Minute)
+
+	guard(breaker, failingCall)
+	guard(breaker, succeedingCall)
+	guard(breaker, failingCall)
+
+	if breaker.State() != BreakerClosed {
+		t.Fatalf("expected closed, got %s", breaker.State())
+	}
+}
+
+func TestCircuitBreaker_HalfOpenProbe(t *testing.T) {
+	breaker, clock := newTestBreaker(1, time.Minute)
+
+	guard(breaker, failingCall)
+	clock.now = clock.now.Add(time.Minute)
+
+	if breaker.State() != BreakerHalfOpen {
+		t.Fatalf("expected half-open after cool-down, got %s", breaker.State())
+	}
+
+	// Only one probe may be in flight.
+	if err := breaker.allow(); err != nil {
+		t.Fatalf("expected probe to be allowed, got %v", err)
+	}
+	if err := breaker.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected second call to be rejected, got %v", err)
+	}
+
+	breaker.record(nil)
+	if breaker.State() != BreakerClosed {
+		t.Fatalf("expected closed after successful probe, got %s", breaker.State())
+	}
+}
+
+func TestCircuitBreaker_FailedProbeReopens(t *testing.T) {
+	breaker, clock := newTestBreaker(3, time.Minute)
+
+	for range 3 {
+		guard(breaker, failingCall)
+	}
+	clock.now = clock.now.Add(time.Minute)
+
+	guard(breaker, failingCall)
+	if breaker.State() != BreakerOpen {
+		t.Fatalf("expected open after failed probe, got %s", breaker.State())
+	}
+}
+
+func TestCircuitBreaker_IgnoresCallerCancellation(t *testing.T) {
+	breaker, _ := newTestBreaker(1, time.Minute)
+
+	guard(breaker, func() (string, error) { return "", context.Canceled })
+
+	if breaker.State() != BreakerClosed {
+		t.Fatalf("expected closed, got %s", breaker.State())
+	}
+}
+
+func TestCircuitBreaker_IgnoresClientErrors(t *testing.T) {
+	breaker, _ := newTestBreaker(1, time.Minute)
+
+	guard(breaker, func() (string, error) {
+		return "", &UpstreamStatusError{StatusCode: 404}
+	})
+
+	if breaker.State() != BreakerClosed {
+		t.Fatalf("expected closed, got %s", breaker.State())
+	}
+}
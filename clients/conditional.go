@@ -0,0 +1,81 @@
+package clients
+
+import (
+	"net/http"
+	"reflect"
+	"sync"
+)
+
+// conditionalEntry holds the validators and decoded value of the last 200
+// response for a URL, so a later 304 can be answered without a body.
+type conditionalEntry struct {
+	etag         string
+	lastModified string
+	value        reflect.Value
+}
+
+func (e conditionalEntry) header() http.Header {
+	header := http.Header{}
+	if e.etag != "" {
+		header.Set("If-None-Match", e.etag)
+	}
+	if e.lastModified != "" {
+		header.Set("If-Modified-Since", e.lastModified)
+	}
+	return header
+}
+
+type conditionalStore struct {
+	mu      sync.RWMutex
+	entries map[string]conditionalEntry
+}
+
+func newConditionalStore() *conditionalStore {
+	return &conditionalStore{
+		entries: make(map[string]conditionalEntry),
+	}
+}
+
+// lookup returns the entry for url if its value can be assigned to a t.
+func (s *conditionalStore) lookup(url string, t reflect.Type) (conditionalEntry, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entry, ok := s.entries[url]
+	if !ok || entry.value.Type() != t {
+		return conditionalEntry{}, false
+	}
+	return entry, true
+}
+
+// remember stores value for url when the response carries a validator.
+func (s *conditionalStore) remember(url string, header http.Header, value reflect.Value) {
+	entry := conditionalEntry{
+		etag:         header.Get("ETag"),
+		lastModified: header.Get("Last-Modified"),
+		value:        clone(value),
+	}
+	if entry.etag == "" && entry.lastModified == "" {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[url] = entry
+}
+
+// clone returns a copy of v detached from the caller's variable, with its
+// own top-level slice backing array, so a caller modifying, sorting or
+// appending to a result doesn't change what a later 304 returns. The copy is
+// shallow: pointers and slices nested inside elements are still shared.
+func clone(v reflect.Value) reflect.Value {
+	copied := reflect.New(v.Type()).Elem()
+	copied.Set(v)
+
+	if v.Kind() == reflect.Slice && !v.IsNil() {
+		copied.Set(reflect.MakeSlice(v.Type(), v.Len(), v.Len()))
+		reflect.Copy(copied, v)
+	}
+	return copied
+}
@@ -0,0 +1,111 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+func TestConditional_ReusesValueOnNotModified(t *testing.T) {
+	var calls atomic.Int32
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.Header.Get("If-None-Match") == `W/"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `W/"v1"`)
+		w.Write([]byte(`[{"id":"1","name":"Starlink"},{"id":"2","name":"CRS-20"}]`))
+	})
+
+	first, err := client.GetPast(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Mutating a result must not leak into the remembered value.
+	first[0].Name = "mutated"
+
+	second, err := client.GetPast(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if calls.Load() != 2 {
+		t.Fatalf("expected 2 upstream calls, got %d", calls.Load())
+	}
+
+	if len(second) != 2 || second[0].Name != "Starlink" {
+		t.Fatalf("unexpected launches after 304: %+v", second)
+	}
+}
+
+func TestConditional_SingleDocumentIsNotShared(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `W/"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `W/"v1"`)
+		w.Write([]byte(`{"id":"1","name":"Starlink"}`))
+	})
+
+	first, err := client.GetNext(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	first.Name = "mutated"
+
+	second, err := client.GetNext(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if second.Name != "Starlink" || second == first {
+		t.Fatalf("expected the remembered launch after 304, got %+v", second)
+	}
+}
+
+func TestConditional_SendsLastModified(t *testing.T) {
+	const lastModified = "Wed, 21 Oct 2015 07:28:00 GMT"
+	var conditional atomic.Bool
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-Modified-Since") == lastModified {
+			conditional.Store(true)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Last-Modified", lastModified)
+		w.Write([]byte(`{"id":"1","name":"Falcon 9"}`))
+	})
+
+	client.GetRocket(context.Background(), "1")
+	rocket, err := client.GetRocket(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !conditional.Load() {
+		t.Fatal("expected a conditional request")
+	}
+
+	if rocket.Name != "Falcon 9" {
+		t.Fatalf("unexpected rocket after 304: %+v", rocket)
+	}
+}
+
+func TestConditional_NoValidatorsMeansUnconditional(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" || r.Header.Get("If-Modified-Since") != "" {
+			t.Error("unexpected conditional request")
+		}
+		w.Write([]byte(`{"id":"1"}`))
+	})
+
+	client.GetNext(context.Background())
+	client.GetNext(context.Background())
+}
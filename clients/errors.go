@@ -0,0 +1,54 @@
+package clients
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+var (
+	ErrNotFound         = errors.New("upstream resource not found")
+	ErrRateLimited      = errors.New("upstream rate limit exceeded")
+	ErrTimeout          = errors.New("upstream request timed out")
+	ErrMalformedPayload = errors.New("malformed upstream payload")
+)
+
+// maxErrorBodyBytes caps how much of an error response body is kept.
+const maxErrorBodyBytes = 512
+
+// UpstreamStatusError is returned when the SpaceX API answers with a non-200 status.
+// It matches ErrNotFound and ErrRateLimited with errors.Is for 404 and 429 responses.
+type UpstreamStatusError struct {
+	StatusCode int
+	URL        string
+	Body       string
+}
+
+func newUpstreamStatusError(url string, response *http.Response) *UpstreamStatusError {
+	body, _ := io.ReadAll(io.LimitReader(response.Body, maxErrorBodyBytes))
+
+	return &UpstreamStatusError{
+		StatusCode: response.StatusCode,
+		URL:        url,
+		Body:       strings.TrimSpace(string(body)),
+	}
+}
+
+func (e *UpstreamStatusError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("unexpected upstream status %d from %s", e.StatusCode, e.URL)
+	}
+	return fmt.Sprintf("unexpected upstream status %d from %s: %s", e.StatusCode, e.URL, e.Body)
+}
+
+func (e *UpstreamStatusError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUpstreamStatusError_NotFound(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`Not Found`))
+	})
+
+	_, err := client.GetRocket(context.Background(), "missing")
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	var statusErr *UpstreamStatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("expected *UpstreamStatusError, got %T", err)
+	}
+
+	if statusErr.StatusCode != http.StatusNotFound || statusErr.Body != "Not Found" {
+		t.Fatalf("unexpected error details: %+v", statusErr)
+	}
+
+	if !strings.HasSuffix(statusErr.URL, "/rockets/missing") {
+		t.Fatalf("unexpected url: %s", statusErr.URL)
+	}
+}
+
+func TestUpstreamStatusError_TruncatesBody(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(strings.Repeat("x", 4*maxErrorBodyBytes)))
+	})
+
+	_, err := client.GetNext(context.Background())
+
+	var statusErr *UpstreamStatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("expected *UpstreamStatusError, got %T", err)
+	}
+
+	if len(statusErr.Body) != maxErrorBodyBytes {
+		t.Fatalf("expected body truncated to %d bytes, got %d", maxErrorBodyBytes, len(statusErr.Body))
+	}
+
+	if errors.Is(err, ErrNotFound) || errors.Is(err, ErrRateLimited) {
+		t.Fatal("400 should not match not-found or rate-limited")
+	}
+}
+
+func TestMalformedPayload(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":`))
+	})
+
+	_, err := client.GetLatest(context.Background())
+
+	if !errors.Is(err, ErrMalformedPayload) {
+		t.Fatalf("expected ErrMalformedPayload, got %v", err)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := client.GetUpcoming(ctx)
+
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("expected ErrTimeout, got %v", err)
+	}
+}
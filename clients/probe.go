@@ -0,0 +1,79 @@
+package clients
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"spacex-tracker/configs"
+)
+
+// probeCacheTTL is how long a probe result is reused, so that frequent
+// readiness checks don't each send a request to the SpaceX API.
+const probeCacheTTL = 10 * time.Second
+
+// NewProbe returns a check that the SpaceX API is reachable. It sends a HEAD
+// request to the base URL: any HTTP answer, even an error status, counts as
+// reachable. It bypasses retries and the circuit breaker so that it reports
+// the live state of upstream, at most probeCacheTTL old.
+func NewProbe(cfg *configs.Config) func(context.Context) error {
+	return cacheProbe(headProbe(cfg), probeCacheTTL, time.Now)
+}
+
+func headProbe(cfg *configs.Config) func(context.Context) error {
+	client := &http.Client{Timeout: cfg.ClientTimeout}
+
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodHead, cfg.ClientBaseURL, nil)
+		if err != nil {
+			return err
+		}
+
+		response, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		io.Copy(io.Discard, response.Body)
+		return response.Body.Close()
+	}
+}
+
+// cacheProbe reuses the result of probe for ttl. Concurrent callers share a
+// single probe, which isn't cut short when one of them gives up waiting.
+func cacheProbe(probe func(context.Context) error, ttl time.Duration, now func() time.Time) func(context.Context) error {
+	var (
+		mu      sync.Mutex
+		checked time.Time
+		last    error
+		group   singleflight.Group
+	)
+
+	return func(ctx context.Context) error {
+		mu.Lock()
+		if !checked.IsZero() && now().Sub(checked) < ttl {
+			defer mu.Unlock()
+			return last
+		}
+		mu.Unlock()
+
+		result := group.DoChan("probe", func() (any, error) {
+			err := probe(context.WithoutCancel(ctx))
+
+			mu.Lock()
+			checked, last = now(), err
+			mu.Unlock()
+			return nil, err
+		})
+
+		select {
+		case res := <-result:
+			return res.Err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"spacex-tracker/configs"
+)
+
+func TestProbe_AnyResponseIsReachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	probe := headProbe(&configs.Config{ClientBaseURL: server.URL, ClientTimeout: time.Second})
+
+	if err := probe(context.Background()); err != nil {
+		t.Fatalf("expected upstream to be reachable, got %v", err)
+	}
+
+	server.Close()
+	if err := probe(context.Background()); err == nil {
+		t.Fatal("expected an error once upstream is gone")
+	}
+}
+
+func TestProbe_ReusesResultWithinTTL(t *testing.T) {
+	clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	calls := 0
+	down := errors.New("down")
+	probe := cacheProbe(func(context.Context) error {
+		calls++
+		return down
+	}, 10*time.Second, func() time.Time { return clock })
+
+	for range 3 {
+		if err := probe(context.Background()); !errors.Is(err, down) {
+			t.Fatalf("expected the cached error, got %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 probe within the TTL, got %d", calls)
+	}
+
+	clock = clock.Add(10 * time.Second)
+	probe(context.Background())
+	if calls != 2 {
+		t.Fatalf("expected a new probe once the TTL passed, got %d", calls)
+	}
+}
@@ -0,0 +1,163 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"spacex-tracker/configs"
+)
+
+func newRetryingClient(t *testing.T, handler http.HandlerFunc) SpaceXClient {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return NewSpaceXClient(&configs.Config{
+		ClientBaseURL:    server.URL,
+		ClientTimeout:    time.Second,
+		RetryMaxAttempts: 3,
+		RetryBaseDelay:   time.Millisecond,
+		RetryMaxDelay:    10 * time.Millisecond,
+		RetryJitter:      0.5,
+	})
+}
+
+func TestRetry_RecoversFromServerErrors(t *testing.T) {
+	var calls atomic.Int32
+
+	client := newRetryingClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"id":"1","name":"Falcon 9"}`))
+	})
+
+	launch, err := client.GetNext(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if launch.Name != "Falcon 9" {
+		t.Fatalf("unexpected launch: %+v", launch)
+	}
+
+	if calls.Load() != 3 {
+		t.Fatalf("expected 3 attempts, got %d", calls.Load())
+	}
+}
+
+func TestRetry_GivesUpAfterMaxAttempts(t *testing.T) {
+	var calls atomic.Int32
+
+	client := newRetryingClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	if _, err := client.GetUpcoming(context.Background()); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	if calls.Load() != 3 {
+		t.Fatalf("expected 3 attempts, got %d", calls.Load())
+	}
+}
+
+func TestRetry_DoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+
+	client := newRetryingClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	if _, err := client.GetRocket(context.Background(), "missing"); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	if calls.Load() != 1 {
+		t.Fatalf("expected 1 attempt, got %d", calls.Load())
+	}
+}
+
+func TestRetry_RateLimitedBeyondMaxDelayIsNotRetried(t *testing.T) {
+	var calls atomic.Int32
+
+	client := newRetryingClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	if _, err := client.GetLatest(context.Background()); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	if calls.Load() != 1 {
+		t.Fatalf("expected 1 attempt, got %d", calls.Load())
+	}
+}
+
+func TestRetry_StopsWhenContextCancelled(t *testing.T) {
+	var calls atomic.Int32
+	ctx, cancel := context.WithCancel(context.Background())
+
+	client := newRetryingClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		cancel()
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	if _, err := client.GetPast(ctx); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	if calls.Load() != 1 {
+		t.Fatalf("expected 1 attempt, got %d", calls.Load())
+	}
+}
+
+func TestRetryPolicy_BackoffIsCapped(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	expected := []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second,
+		time.Second,
+	}
+
+	for i, want := range expected {
+		if got := policy.backoff(i + 1); got != want {
+			t.Errorf("attempt %d: expected %v, got %v", i+1, want, got)
+		}
+	}
+}
+
+func TestRetryAfter_ParsesSecondsAndDates(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	header := http.Header{}
+	header.Set("Retry-After", "3")
+	if d, ok := retryAfter(header, now); !ok || d != 3*time.Second {
+		t.Fatalf("expected 3s, got %v (ok=%v)", d, ok)
+	}
+
+	header.Set("Retry-After", now.Add(5*time.Second).Format(http.TimeFormat))
+	if d, ok := retryAfter(header, now); !ok || d != 5*time.Second {
+		t.Fatalf("expected 5s, got %v (ok=%v)", d, ok)
+	}
+
+	header.Set("Retry-After", "soon")
+	if _, ok := retryAfter(header, now); ok {
+		t.Fatal("expected invalid header to be ignored")
+	}
+}
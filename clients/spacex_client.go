@@ -1,12 +1,19 @@
 package clients
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"reflect"
+	"time"
 
 	"spacex-tracker/configs"
+	"spacex-tracker/logging"
 	"spacex-tracker/models"
 )
 
@@ -15,86 +22,290 @@ type SpaceXClient interface {
 	GetLatest(ctx context.Context) (*models.Launch, error)
 	GetUpcoming(ctx context.Context) ([]models.Launch, error)
 	GetPast(ctx context.Context) ([]models.Launch, error)
+	GetLaunch(ctx context.Context, id string) (*models.Launch, error)
+	QueryLaunches(ctx context.Context, query models.Query) (*models.Page[models.Launch], error)
+
+	GetRocket(ctx context.Context, id string) (*models.Rocket, error)
+	GetRockets(ctx context.Context) ([]models.Rocket, error)
+	GetLaunchpad(ctx context.Context, id string) (*models.Launchpad, error)
+	GetLaunchpads(ctx context.Context) ([]models.Launchpad, error)
+	GetLandpad(ctx context.Context, id string) (*models.Landpad, error)
+	GetLandpads(ctx context.Context) ([]models.Landpad, error)
+	GetPayload(ctx context.Context, id string) (*models.Payload, error)
+	GetPayloads(ctx context.Context) ([]models.Payload, error)
+	GetCore(ctx context.Context, id string) (*models.Core, error)
+	GetCores(ctx context.Context) ([]models.Core, error)
+	GetCapsule(ctx context.Context, id string) (*models.Capsule, error)
+	GetCapsules(ctx context.Context) ([]models.Capsule, error)
+	GetCrewMember(ctx context.Context, id string) (*models.CrewMember, error)
+	GetCrew(ctx context.Context) ([]models.CrewMember, error)
+	GetShip(ctx context.Context, id string) (*models.Ship, error)
+	GetShips(ctx context.Context) ([]models.Ship, error)
 }
 
 type concreteSpaceXClient struct {
 	base_url string
 	client   *http.Client
+	retry    RetryPolicy
+
+	conditional *conditionalStore
 }
 
 func NewSpaceXClient(cfg *configs.Config) SpaceXClient {
 	return &concreteSpaceXClient{
 		base_url: cfg.ClientBaseURL,
 		client: &http.Client{
-			Timeout: cfg.ClientTimeout,
+			Timeout:   cfg.ClientTimeout,
+			Transport: &tracingTransport{base: http.DefaultTransport},
 		},
+		retry:       NewRetryPolicy(cfg),
+		conditional: newConditionalStore(),
 	}
 }
 
-func (c *concreteSpaceXClient) getOne(ctx context.Context, url string) (*models.Launch, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, err
+// send issues a request to url, retrying transient failures according to the
+// client's RetryPolicy. A 200 or 304 response is returned to the caller, who
+// then owns its body.
+func (c *concreteSpaceXClient) send(ctx context.Context, method string, url string, payload []byte, header http.Header) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		var body io.Reader
+		if payload != nil {
+			body = bytes.NewReader(payload)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, url, body)
+		if err != nil {
+			return nil, err
+		}
+		for key, values := range header {
+			req.Header[key] = values
+		}
+		if payload != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		response, err := c.client.Do(req)
+
+		var delay time.Duration
+		retryable := false
+		switch {
+		case err != nil:
+			retryable = isRetryableError(err)
+			delay = c.retry.backoff(attempt)
+			if isTimeout(err) {
+				err = fmt.Errorf("%w: %w", ErrTimeout, err)
+			}
+		case response.StatusCode == http.StatusOK, response.StatusCode == http.StatusNotModified:
+			return response, nil
+		default:
+			retryable = isRetryableStatus(response.StatusCode)
+			delay = c.retry.backoff(attempt)
+			if wait, ok := retryAfter(response.Header, time.Now()); ok {
+				// Don't retry when upstream asks us to wait longer than we're willing to.
+				retryable = retryable && wait <= c.retry.MaxDelay
+				delay = wait
+			}
+			err = newUpstreamStatusError(url, response)
+			io.Copy(io.Discard, response.Body)
+			response.Body.Close()
+		}
+
+		retrying := retryable && attempt < c.retry.attempts() && ctx.Err() == nil
+		logUpstreamFailure(ctx, method, url, attempt, retrying, err)
+		if !retrying {
+			return nil, err
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func logUpstreamFailure(ctx context.Context, method string, url string, attempt int, retrying bool, err error) {
+	attrs := []any{"method", method, "url", url, "attempt", attempt, "retrying", retrying, "error", err}
+
+	var statusErr *UpstreamStatusError
+	if errors.As(err, &statusErr) {
+		attrs = append(attrs, "status", statusErr.StatusCode)
+	}
+	logging.FromContext(ctx).Warn("upstream request failed", attrs...)
+}
+
+// do sends a request to url, with body encoded as JSON when it is non-nil,
+// and decodes the JSON response into out. GET requests are made conditional
+// on the validators of the last response for url; on a 304 the previously
+// decoded value is reused.
+func (c *concreteSpaceXClient) do(ctx context.Context, method string, url string, body any, out any) error {
+	var payload []byte
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		payload = encoded
+	}
+
+	target := reflect.ValueOf(out).Elem()
+
+	var header http.Header
+	cached, conditional := conditionalEntry{}, false
+	if method == http.MethodGet {
+		cached, conditional = c.conditional.lookup(url, target.Type())
+		if conditional {
+			header = cached.header()
+		}
 	}
 
-	response, err := c.client.Do(req)
+	response, err := c.send(ctx, method, url, payload, header)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer response.Body.Close()
 
-	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Unexpected status: %d", response.StatusCode)
+	if response.StatusCode == http.StatusNotModified {
+		if !conditional {
+			return newUpstreamStatusError(url, response)
+		}
+		target.Set(clone(cached.value))
+		return nil
 	}
 
-	var launch models.Launch
-	if err := json.NewDecoder(response.Body).Decode(&launch); err != nil {
-		return nil, err
+	if err := json.NewDecoder(response.Body).Decode(out); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrMalformedPayload, url, err)
 	}
 
-	return &launch, nil
+	if method == http.MethodGet {
+		c.conditional.remember(url, response.Header, target)
+	}
+
+	return nil
 }
 
-func (c *concreteSpaceXClient) getList(ctx context.Context, url string) ([]models.Launch, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
+func getOne[T any](ctx context.Context, c *concreteSpaceXClient, url string) (*T, error) {
+	var result T
+	if err := c.do(ctx, http.MethodGet, url, nil, &result); err != nil {
 		return nil, err
 	}
 
-	response, err := c.client.Do(req)
-	if err != nil {
+	return &result, nil
+}
+
+func getList[T any](ctx context.Context, c *concreteSpaceXClient, url string) ([]T, error) {
+	var results []T
+	if err := c.do(ctx, http.MethodGet, url, nil, &results); err != nil {
 		return nil, err
 	}
-	defer response.Body.Close()
 
-	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Unexpected status: %d", response.StatusCode)
-	}
+	return results, nil
+}
 
-	var launches []models.Launch
-	if err := json.NewDecoder(response.Body).Decode(&launches); err != nil {
-		return nil, err
-	}
+func (c *concreteSpaceXClient) collectionURL(resource string) string {
+	return fmt.Sprintf("%s/%s", c.base_url, resource)
+}
 
-	return launches, nil
+func (c *concreteSpaceXClient) documentURL(resource string, id string) string {
+	return fmt.Sprintf("%s/%s/%s", c.base_url, resource, url.PathEscape(id))
 }
 
 func (c *concreteSpaceXClient) GetNext(ctx context.Context) (*models.Launch, error) {
 	url := fmt.Sprintf("%s/launches/next", c.base_url)
-	return c.getOne(ctx, url)
+	return getOne[models.Launch](ctx, c, url)
 }
 
 func (c *concreteSpaceXClient) GetLatest(ctx context.Context) (*models.Launch, error) {
 	url := fmt.Sprintf("%s/launches/latest", c.base_url)
-	return c.getOne(ctx, url)
+	return getOne[models.Launch](ctx, c, url)
 }
 
 func (c *concreteSpaceXClient) GetUpcoming(ctx context.Context) ([]models.Launch, error) {
 	url := fmt.Sprintf("%s/launches/upcoming", c.base_url)
-	return c.getList(ctx, url)
+	return getList[models.Launch](ctx, c, url)
 }
 
 func (c *concreteSpaceXClient) GetPast(ctx context.Context) ([]models.Launch, error) {
 	url := fmt.Sprintf("%s/launches/past", c.base_url)
-	return c.getList(ctx, url)
+	return getList[models.Launch](ctx, c, url)
+}
+
+func (c *concreteSpaceXClient) GetLaunch(ctx context.Context, id string) (*models.Launch, error) {
+	return getOne[models.Launch](ctx, c, c.documentURL("launches", id))
+}
+
+func (c *concreteSpaceXClient) QueryLaunches(ctx context.Context, query models.Query) (*models.Page[models.Launch], error) {
+	url := fmt.Sprintf("%s/launches/query", c.base_url)
+	if query.Query == nil {
+		query.Query = map[string]any{} // upstream rejects a null query
+	}
+
+	var page models.Page[models.Launch]
+	if err := c.do(ctx, http.MethodPost, url, query, &page); err != nil {
+		return nil, err
+	}
+
+	return &page, nil
+}
+
+func (c *concreteSpaceXClient) GetRocket(ctx context.Context, id string) (*models.Rocket, error) {
+	return getOne[models.Rocket](ctx, c, c.documentURL("rockets", id))
+}
+
+func (c *concreteSpaceXClient) GetRockets(ctx context.Context) ([]models.Rocket, error) {
+	return getList[models.Rocket](ctx, c, c.collectionURL("rockets"))
+}
+
+func (c *concreteSpaceXClient) GetLaunchpad(ctx context.Context, id string) (*models.Launchpad, error) {
+	return getOne[models.Launchpad](ctx, c, c.documentURL("launchpads", id))
+}
+
+func (c *concreteSpaceXClient) GetLaunchpads(ctx context.Context) ([]models.Launchpad, error) {
+	return getList[models.Launchpad](ctx, c, c.collectionURL("launchpads"))
+}
+
+func (c *concreteSpaceXClient) GetLandpad(ctx context.Context, id string) (*models.Landpad, error) {
+	return getOne[models.Landpad](ctx, c, c.documentURL("landpads", id))
+}
+
+func (c *concreteSpaceXClient) GetLandpads(ctx context.Context) ([]models.Landpad, error) {
+	return getList[models.Landpad](ctx, c, c.collectionURL("landpads"))
+}
+
+func (c *concreteSpaceXClient) GetPayload(ctx context.Context, id string) (*models.Payload, error) {
+	return getOne[models.Payload](ctx, c, c.documentURL("payloads", id))
+}
+
+func (c *concreteSpaceXClient) GetPayloads(ctx context.Context) ([]models.Payload, error) {
+	return getList[models.Payload](ctx, c, c.collectionURL("payloads"))
+}
+
+func (c *concreteSpaceXClient) GetCore(ctx context.Context, id string) (*models.Core, error) {
+	return getOne[models.Core](ctx, c, c.documentURL("cores", id))
+}
+
+func (c *concreteSpaceXClient) GetCores(ctx context.Context) ([]models.Core, error) {
+	return getList[models.Core](ctx, c, c.collectionURL("cores"))
+}
+
+func (c *concreteSpaceXClient) GetCapsule(ctx context.Context, id string) (*models.Capsule, error) {
+	return getOne[models.Capsule](ctx, c, c.documentURL("capsules", id))
+}
+
+func (c *concreteSpaceXClient) GetCapsules(ctx context.Context) ([]models.Capsule, error) {
+	return getList[models.Capsule](ctx, c, c.collectionURL("capsules"))
+}
+
+func (c *concreteSpaceXClient) GetCrewMember(ctx context.Context, id string) (*models.CrewMember, error) {
+	return getOne[models.CrewMember](ctx, c, c.documentURL("crew", id))
+}
+
+func (c *concreteSpaceXClient) GetCrew(ctx context.Context) ([]models.CrewMember, error) {
+	return getList[models.CrewMember](ctx, c, c.collectionURL("crew"))
+}
+
+func (c *concreteSpaceXClient) GetShip(ctx context.Context, id string) (*models.Ship, error) {
+	return getOne[models.Ship](ctx, c, c.documentURL("ships", id))
+}
+
+func (c *concreteSpaceXClient) GetShips(ctx context.Context) ([]models.Ship, error) {
+	return getList[models.Ship](ctx, c, c.collectionURL("ships"))
 }
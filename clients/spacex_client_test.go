@@ -0,0 +1,171 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"spacex-tracker/configs"
+	"spacex-tracker/logging"
+	"spacex-tracker/models"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) SpaceXClient {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return NewSpaceXClient(&configs.Config{
+		ClientBaseURL: server.URL,
+		ClientTimeout: time.Second,
+	})
+}
+
+func TestGetRocket_Success(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rockets/5e9d0d95eda69973a809d1ec" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"id":"5e9d0d95eda69973a809d1ec","name":"Falcon 9","stages":2,"height":{"meters":70}}`))
+	})
+
+	rocket, err := client.GetRocket(context.Background(), "5e9d0d95eda69973a809d1ec")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if rocket.Name != "Falcon 9" || rocket.Stages != 2 {
+		t.Fatalf("unexpected rocket: %+v", rocket)
+	}
+
+	if rocket.Height.Meters == nil || *rocket.Height.Meters != 70 {
+		t.Fatalf("unexpected height: %+v", rocket.Height)
+	}
+}
+
+func TestGetLaunch_ByID(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/launches/5eb87cd9ffd86e000604b32a" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"id":"5eb87cd9ffd86e000604b32a","name":"FalconSat","flight_number":1}`))
+	})
+
+	launch, err := client.GetLaunch(context.Background(), "5eb87cd9ffd86e000604b32a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if launch.Name != "FalconSat" || launch.FlightNumber != 1 {
+		t.Fatalf("unexpected launch: %+v", launch)
+	}
+
+	if _, err := client.GetLaunch(context.Background(), "5eb87cd9ffd86e000604b32b"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestGetLaunchpads_Success(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/launchpads" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Write([]byte(`[{"id":"1","name":"SLC 40"},{"id":"2","name":"LC 39A"}]`))
+	})
+
+	launchpads, err := client.GetLaunchpads(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(launchpads) != 2 || launchpads[1].Name != "LC 39A" {
+		t.Fatalf("unexpected launchpads: %+v", launchpads)
+	}
+}
+
+func TestGetCrewMember_UnexpectedStatus(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	_, err := client.GetCrewMember(context.Background(), "missing")
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestQueryLaunches_SendsBodyAndDecodesPage(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/launches/query" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("invalid body: %v", err)
+		}
+
+		options := body["options"].(map[string]any)
+		if options["limit"] != float64(2) || options["page"] != float64(3) {
+			t.Errorf("unexpected options: %v", options)
+		}
+		if body["query"].(map[string]any)["upcoming"] != false {
+			t.Errorf("unexpected query: %v", body["query"])
+		}
+
+		w.Write([]byte(`{"docs":[{"id":"a","name":"CRS-20"}],"totalDocs":5,"limit":2,"page":3,"totalPages":3,"hasPrevPage":true,"hasNextPage":false,"prevPage":2,"nextPage":null}`))
+	})
+
+	page, err := client.QueryLaunches(context.Background(), models.Query{
+		Query: map[string]any{"upcoming": false},
+		Options: models.QueryOptions{
+			Sort:  map[string]string{"date_utc": "desc"},
+			Limit: 2,
+			Page:  3,
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(page.Docs) != 1 || page.Docs[0].Name != "CRS-20" {
+		t.Fatalf("unexpected docs: %+v", page.Docs)
+	}
+
+	if page.TotalDocs != 5 || page.HasNextPage || page.NextPage != nil {
+		t.Fatalf("unexpected page metadata: %+v", page)
+	}
+
+	if page.PrevPage == nil || *page.PrevPage != 2 {
+		t.Fatalf("unexpected prev page: %v", page.PrevPage)
+	}
+}
+
+func TestUpstreamFailure_LoggedWithStatusAndURL(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+
+	var buf bytes.Buffer
+	ctx := logging.WithLogger(context.Background(), slog.New(slog.NewJSONHandler(&buf, nil)))
+	client.GetRocket(ctx, "missing")
+
+	var entry struct {
+		Msg    string `json:"msg"`
+		URL    string `json:"url"`
+		Status int    `json:"status"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("expected one JSON log line, got %q", buf.String())
+	}
+	if entry.Msg != "upstream request failed" || entry.Status != 404 || entry.URL == "" {
+		t.Fatalf("unexpected log entry %+v", entry)
+	}
+}
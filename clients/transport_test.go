@@ -0,0 +1,46 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracingTransport_RecordsAttemptsAndPropagates(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+
+	var traceparent string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		http.NotFound(w, r)
+	})
+
+	if _, err := client.GetRocket(context.Background(), "missing"); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected one attempt span, got %d", len(spans))
+	}
+	if traceparent == "" || traceparent[3:35] != spans[0].SpanContext().TraceID().String() {
+		t.Fatalf("traceparent %q does not match span %s", traceparent, spans[0].SpanContext().TraceID())
+	}
+
+	attrs := map[string]string{}
+	for _, kv := range spans[0].Attributes() {
+		attrs[string(kv.Key)] = kv.Value.Emit()
+	}
+	if attrs["http.response.status_code"] != "404" || attrs["url.full"] == "" {
+		t.Fatalf("unexpected attributes %v", attrs)
+	}
+}
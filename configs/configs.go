@@ -1,6 +1,7 @@
 package configs
 
 import (
+	"errors"
 	"os"
 	"strconv"
 	"time"
@@ -9,12 +10,50 @@ import (
 )
 
 type Config struct {
+	HTTPAddr              string
+	HTTPReadTimeout       time.Duration
+	HTTPReadHeaderTimeout time.Duration
+	HTTPWriteTimeout      time.Duration
+	HTTPIdleTimeout       time.Duration
+	HTTPMaxHeaderBytes    int
+	TLSCertFile           string
+	TLSKeyFile            string
+	ShutdownTimeout       time.Duration
+
 	RedisURL string
 
 	ClientBaseURL string
 	ClientTimeout time.Duration
 
-	CacheTTL time.Duration
+	RetryMaxAttempts int
+	RetryBaseDelay   time.Duration
+	RetryMaxDelay    time.Duration
+	RetryJitter      float64
+
+	BreakerFailureThreshold int
+	BreakerCooldown         time.Duration
+
+	CacheTTL         time.Duration
+	CacheTTLNext     time.Duration
+	CacheTTLLatest   time.Duration
+	CacheTTLUpcoming time.Duration
+	CacheTTLPast     time.Duration
+	CacheAdaptiveTTL bool
+	CacheStaleTTL    time.Duration
+	CacheNegativeTTL time.Duration
+	CacheCompression string
+
+	MemoryCacheSize int
+	MemoryCacheTTL  time.Duration
+
+	WarmerInterval time.Duration
+	WarmerJitter   float64
+
+	AdminToken string
+
+	TracingExporter string
+
+	LogLevel string
 }
 
 func getEnv(key, fallback string) string {
@@ -24,23 +63,193 @@ func getEnv(key, fallback string) string {
 	return fallback
 }
 
+func getEnvInt(key string, fallback int) (int, error) {
+	return strconv.Atoi(getEnv(key, strconv.Itoa(fallback)))
+}
+
+func getEnvFloat(key string, fallback float64) (float64, error) {
+	return strconv.ParseFloat(getEnv(key, strconv.FormatFloat(fallback, 'f', -1, 64)), 64)
+}
+
+func getEnvBool(key string, fallback bool) (bool, error) {
+	return strconv.ParseBool(getEnv(key, strconv.FormatBool(fallback)))
+}
+
 func Load() (*Config, error) {
 	_ = godotenv.Load() // safe for local, ignored in container
 
+	readTimeout, err := getEnvInt("HTTP_READ_TIMEOUT", 10)
+	if err != nil {
+		return nil, err
+	}
+
+	readHeaderTimeout, err := getEnvInt("HTTP_READ_HEADER_TIMEOUT", 5)
+	if err != nil {
+		return nil, err
+	}
+
+	// Long enough for an upstream call to exhaust its retries.
+	writeTimeout, err := getEnvInt("HTTP_WRITE_TIMEOUT", 30)
+	if err != nil {
+		return nil, err
+	}
+
+	idleTimeout, err := getEnvInt("HTTP_IDLE_TIMEOUT", 120)
+	if err != nil {
+		return nil, err
+	}
+
+	maxHeaderBytes, err := getEnvInt("HTTP_MAX_HEADER_BYTES", 1<<20)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsCert, tlsKey := getEnv("TLS_CERT_FILE", ""), getEnv("TLS_KEY_FILE", "")
+	if (tlsCert == "") != (tlsKey == "") {
+		return nil, errors.New("TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	}
+
+	shutdownTimeout, err := getEnvInt("SHUTDOWN_TIMEOUT", 15)
+	if err != nil {
+		return nil, err
+	}
+
 	timeout, err := strconv.Atoi(getEnv("CLIENT_TIMEOUT", "5"))
 	if err != nil {
 		return nil, err
 	}
 
+	retryAttempts, err := getEnvInt("RETRY_MAX_ATTEMPTS", 3)
+	if err != nil {
+		return nil, err
+	}
+
+	retryBaseDelay, err := getEnvInt("RETRY_BASE_DELAY_MS", 200)
+	if err != nil {
+		return nil, err
+	}
+
+	retryMaxDelay, err := getEnvInt("RETRY_MAX_DELAY_MS", 5000)
+	if err != nil {
+		return nil, err
+	}
+	if retryMaxDelay <= 0 || retryMaxDelay < retryBaseDelay {
+		return nil, errors.New("RETRY_MAX_DELAY_MS must be positive and at least RETRY_BASE_DELAY_MS")
+	}
+
+	retryJitter, err := getEnvFloat("RETRY_JITTER", 0.2)
+	if err != nil {
+		return nil, err
+	}
+
+	breakerThreshold, err := getEnvInt("BREAKER_FAILURE_THRESHOLD", 5)
+	if err != nil {
+		return nil, err
+	}
+
+	breakerCooldown, err := getEnvInt("BREAKER_COOLDOWN", 30)
+	if err != nil {
+		return nil, err
+	}
+
 	ttl, err := strconv.Atoi(getEnv("CACHE_TTL", "60"))
 	if err != nil {
 		return nil, err
 	}
 
+	ttlNext, err := getEnvInt("CACHE_TTL_NEXT", ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	ttlLatest, err := getEnvInt("CACHE_TTL_LATEST", ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	ttlUpcoming, err := getEnvInt("CACHE_TTL_UPCOMING", ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	// Past launches rarely change, so they are kept much longer by default.
+	ttlPast, err := getEnvInt("CACHE_TTL_PAST", max(ttl, 3600))
+	if err != nil {
+		return nil, err
+	}
+
+	adaptiveTTL, err := getEnvBool("CACHE_ADAPTIVE_TTL", true)
+	if err != nil {
+		return nil, err
+	}
+
+	staleTTL, err := getEnvInt("CACHE_STALE_TTL", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	negativeTTL, err := getEnvInt("CACHE_NEGATIVE_TTL", 5)
+	if err != nil {
+		return nil, err
+	}
+
+	memorySize, err := getEnvInt("MEMORY_CACHE_SIZE", 1000)
+	if err != nil {
+		return nil, err
+	}
+
+	memoryTTL, err := getEnvInt("MEMORY_CACHE_TTL", 10)
+	if err != nil {
+		return nil, err
+	}
+	if memoryTTL <= 0 {
+		return nil, errors.New("MEMORY_CACHE_TTL must be at least 1 second")
+	}
+
+	warmerInterval, err := getEnvInt("WARMER_INTERVAL", 50)
+	if err != nil {
+		return nil, err
+	}
+
+	warmerJitter, err := getEnvFloat("WARMER_JITTER", 0.1)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Config{
+		HTTPAddr: getEnv("HTTP_ADDR", ":8080"),
+		HTTPReadTimeout: time.Duration(readTimeout)*time.Second,
+		HTTPReadHeaderTimeout: time.Duration(readHeaderTimeout)*time.Second,
+		HTTPWriteTimeout: time.Duration(writeTimeout)*time.Second,
+		HTTPIdleTimeout: time.Duration(idleTimeout)*time.Second,
+		HTTPMaxHeaderBytes: maxHeaderBytes,
+		TLSCertFile: tlsCert,
+		TLSKeyFile: tlsKey,
+		ShutdownTimeout: time.Duration(shutdownTimeout)*time.Second,
 		RedisURL: getEnv("REDIS_URL", ""),
 		ClientBaseURL: getEnv("CLIENT_BASE_URL", "https://api.spacexdata.com/v4"),
 		ClientTimeout: time.Duration(timeout)*time.Second,
+		RetryMaxAttempts: retryAttempts,
+		RetryBaseDelay: time.Duration(retryBaseDelay)*time.Millisecond,
+		RetryMaxDelay: time.Duration(retryMaxDelay)*time.Millisecond,
+		RetryJitter: retryJitter,
+		BreakerFailureThreshold: breakerThreshold,
+		BreakerCooldown: time.Duration(breakerCooldown)*time.Second,
 		CacheTTL: time.Duration(ttl)*time.Second,
+		CacheTTLNext: time.Duration(ttlNext)*time.Second,
+		CacheTTLLatest: time.Duration(ttlLatest)*time.Second,
+		CacheTTLUpcoming: time.Duration(ttlUpcoming)*time.Second,
+		CacheTTLPast: time.Duration(ttlPast)*time.Second,
+		CacheAdaptiveTTL: adaptiveTTL,
+		CacheStaleTTL: time.Duration(staleTTL)*time.Second,
+		CacheNegativeTTL: time.Duration(negativeTTL)*time.Second,
+		CacheCompression: getEnv("CACHE_COMPRESSION", "gzip"),
+		MemoryCacheSize: memorySize,
+		MemoryCacheTTL: time.Duration(memoryTTL)*time.Second,
+		WarmerInterval: time.Duration(warmerInterval)*time.Second,
+		WarmerJitter: warmerJitter,
+		AdminToken: getEnv("ADMIN_TOKEN", ""),
+		TracingExporter: getEnv("TRACING_EXPORTER", "none"),
+		LogLevel: getEnv("LOG_LEVEL", "info"),
 	}, nil
-}
\ No newline at end of file
+}
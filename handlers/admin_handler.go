@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"spacex-tracker/services"
+	"spacex-tracker/services/cache"
+)
+
+type AdminHandler struct {
+	cache     cache.Cache
+	refresher services.Refresher
+}
+
+func NewAdminHandler(cache cache.Cache, refresher services.Refresher) *AdminHandler {
+	return &AdminHandler{
+		cache:     cache,
+		refresher: refresher,
+	}
+}
+
+// RequireToken rejects requests that don't carry "Authorization: Bearer <token>".
+func RequireToken(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		provided, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "unauthorized",
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+type cacheKey struct {
+	Key        string  `json:"key"`
+	TTLSeconds float64 `json:"ttl_seconds"` // -1 when the key never expires
+}
+
+func (h *AdminHandler) ListKeys(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	keys, err := h.cache.Keys(ctx, services.LaunchKeyPattern)
+	if err != nil {
+		writeError(c, err, "failed to list cache keys")
+		return
+	}
+
+	stats, err := h.cache.Stats(ctx)
+	if err != nil {
+		writeError(c, err, "failed to read cache stats")
+		return
+	}
+
+	result := make([]cacheKey, 0, len(keys))
+	for _, key := range keys {
+		ttl := float64(-1)
+		if key.TTL >= 0 {
+			ttl = key.TTL.Seconds()
+		}
+		result = append(result, cacheKey{Key: services.LogicalKey(key.Key), TTLSeconds: ttl})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"keys":  result,
+		"stats": stats,
+	})
+}
+
+// PurgeKey deletes one key as listed by ListKeys, without the schema version
+// namespace, e.g. "launch:next".
+func (h *AdminHandler) PurgeKey(c *gin.Context) {
+	key := services.VersionedKey(services.LogicalKey(c.Param("key")))
+	if err := h.cache.Delete(c.Request.Context(), key); err != nil {
+		writeError(c, err, "failed to purge cache key")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *AdminHandler) PurgeAll(c *gin.Context) {
+	deleted, err := h.cache.DeleteMatching(c.Request.Context(), services.LaunchKeyPattern)
+	if err != nil {
+		writeError(c, err, "failed to purge cache")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"deleted": deleted,
+	})
+}
+
+func (h *AdminHandler) Refresh(c *gin.Context) {
+	err := h.refresher.Refresh(c.Request.Context(), c.Param("endpoint"))
+	if errors.Is(err, services.ErrUnknownEndpoint) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "endpoint must be one of next, latest, upcoming, past",
+		})
+		return
+	}
+	if err != nil {
+		writeError(c, err, "failed to refresh endpoint")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"spacex-tracker/services"
+	"spacex-tracker/services/cache"
+)
+
+type mockRefresher struct {
+	endpoint string
+	err      error
+}
+
+func (m *mockRefresher) Refresh(ctx context.Context, endpoint string) error {
+	m.endpoint = endpoint
+	if endpoint == "rockets" {
+		return fmt.Errorf("%w: %q", services.ErrUnknownEndpoint, endpoint)
+	}
+	return m.err
+}
+
+func setupAdminRouter(c cache.Cache, refresher services.Refresher) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	admin := NewAdminHandler(c, refresher)
+
+	r := gin.New()
+	group := r.Group("/admin", RequireToken("secret"))
+	{
+		group.GET("/cache", admin.ListKeys)
+		group.DELETE("/cache", admin.PurgeAll)
+		group.DELETE("/cache/:key", admin.PurgeKey)
+		group.POST("/cache/refresh/:endpoint", admin.Refresh)
+	}
+
+	return r
+}
+
+func adminRequest(router *gin.Engine, method, path, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAdmin_RequiresToken(t *testing.T) {
+	router := setupAdminRouter(cache.NewMemoryCache(10), &mockRefresher{})
+
+	for _, token := range []string{"", "wrong"} {
+		if w := adminRequest(router, http.MethodGet, "/admin/cache", token); w.Code != http.StatusUnauthorized {
+			t.Fatalf("token %q: expected 401, got %d", token, w.Code)
+		}
+	}
+}
+
+func TestAdmin_ListKeys(t *testing.T) {
+	store := cache.NewMemoryCache(10)
+	store.Set(context.Background(), services.VersionedKey("launch:next"), []byte("{}"), time.Minute)
+	store.Set(context.Background(), "unrelated", []byte("{}"), time.Minute)
+
+	w := adminRequest(setupAdminRouter(store, &mockRefresher{}), http.MethodGet, "/admin/cache", "secret")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	var body struct {
+		Keys  []cacheKey  `json:"keys"`
+		Stats cache.Stats `json:"stats"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid body: %v", err)
+	}
+
+	if len(body.Keys) != 1 || body.Keys[0].Key != "launch:next" || body.Keys[0].TTLSeconds <= 0 {
+		t.Fatalf("unexpected keys: %+v", body.Keys)
+	}
+
+	if body.Stats.Keys != 2 {
+		t.Fatalf("unexpected stats: %+v", body.Stats)
+	}
+}
+
+func TestAdmin_PurgeKeyAndAll(t *testing.T) {
+	store := cache.NewMemoryCache(10)
+	ctx := context.Background()
+	store.Set(ctx, services.VersionedKey("launch:next"), []byte("{}"), 0)
+	store.Set(ctx, services.VersionedKey("launch:latest"), []byte("{}"), 0)
+	store.Set(ctx, services.VersionedKey("launch:upcoming"), []byte("{}"), 0)
+	store.Set(ctx, services.VersionedKey("launch:past"), []byte("{}"), 0)
+
+	router := setupAdminRouter(store, &mockRefresher{})
+
+	if w := adminRequest(router, http.MethodDelete, "/admin/cache/launch:next", "secret"); w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", w.Code)
+	}
+	if _, err := store.Get(ctx, services.VersionedKey("launch:next")); err == nil {
+		t.Fatal("expected launch:next to be purged")
+	}
+
+	// The key as stored, with its version prefix, is accepted too.
+	if w := adminRequest(router, http.MethodDelete, "/admin/cache/"+services.VersionedKey("launch:latest"), "secret"); w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", w.Code)
+	}
+	if _, err := store.Get(ctx, services.VersionedKey("launch:latest")); err == nil {
+		t.Fatal("expected launch:latest to be purged")
+	}
+
+	w := adminRequest(router, http.MethodDelete, "/admin/cache", "secret")
+	if w.Code != http.StatusOK || w.Body.String() != `{"deleted":2}` {
+		t.Fatalf("unexpected response %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestAdmin_Refresh(t *testing.T) {
+	refresher := &mockRefresher{}
+	router := setupAdminRouter(cache.NewMemoryCache(10), refresher)
+
+	if w := adminRequest(router, http.MethodPost, "/admin/cache/refresh/next", "secret"); w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", w.Code)
+	}
+	if refresher.endpoint != "next" {
+		t.Fatalf("unexpected endpoint refreshed: %q", refresher.endpoint)
+	}
+
+	if w := adminRequest(router, http.MethodPost, "/admin/cache/refresh/rockets", "secret"); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+}
@@ -0,0 +1,18 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"spacex-tracker/services"
+)
+
+// setCacheHeaders reports how the cache answered the request.
+func setCacheHeaders(c *gin.Context, info *services.CacheInfo) {
+	if info.Status == "" {
+		return
+	}
+
+	c.Header("X-Cache", string(info.Status))
+	if info.Status == services.CacheStale {
+		c.Header("Warning", `110 - "Response is Stale"`)
+	}
+}
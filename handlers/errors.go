@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"spacex-tracker/clients"
+	"spacex-tracker/logging"
+)
+
+// statusFor maps a service error to the HTTP status returned to our callers.
+func statusFor(err error) int {
+	var statusErr *clients.UpstreamStatusError
+
+	switch {
+	case errors.Is(err, clients.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, clients.ErrRateLimited):
+		return http.StatusTooManyRequests
+	case errors.Is(err, clients.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, clients.ErrCircuitOpen):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, clients.ErrMalformedPayload), errors.As(err, &statusErr):
+		return http.StatusBadGateway
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// writeError logs err with the request's logger and answers with message and
+// the status mapped from err. The error itself isn't exposed to callers.
+func writeError(c *gin.Context, err error, message string) {
+	status := statusFor(err)
+
+	attrs := []any{"status", status, "error", err}
+	var statusErr *clients.UpstreamStatusError
+	if errors.As(err, &statusErr) {
+		attrs = append(attrs, "upstream_status", statusErr.StatusCode, "upstream_url", statusErr.URL)
+	}
+
+	level := slog.LevelWarn
+	if status >= http.StatusInternalServerError {
+		level = slog.LevelError
+	}
+	logging.FromContext(c.Request.Context()).Log(c.Request.Context(), level, message, attrs...)
+
+	c.JSON(status, gin.H{
+		"error": message,
+	})
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"spacex-tracker/clients"
+)
+
+// Check reports whether a dependency is usable.
+type Check func(ctx context.Context) error
+
+const (
+	StatusOK       = "ok"
+	StatusDegraded = "degraded"
+	StatusDown     = "down"
+)
+
+// Cache tiers reported by the readiness probe.
+const (
+	CacheTierNone   = "none"
+	CacheTierMemory = "memory"
+	CacheTierRedis  = "redis"
+	CacheTierTiered = "memory+redis"
+)
+
+// readinessTimeout bounds how long the dependency checks may take together.
+const readinessTimeout = 2 * time.Second
+
+type HealthConfig struct {
+	// Redis pings Redis; nil when Redis isn't in use.
+	Redis Check
+	// Upstream checks that the SpaceX API is reachable.
+	Upstream Check
+	// Breaker guards upstream calls; nil when there is none, and then the
+	// upstream check reports no circuit.
+	Breaker   *clients.CircuitBreaker
+	CacheTier string
+	Version   string
+}
+
+type HealthHandler struct {
+	cfg     HealthConfig
+	started time.Time
+	now     func() time.Time
+}
+
+func NewHealthHandler(cfg HealthConfig) *HealthHandler {
+	return &HealthHandler{
+		cfg:     cfg,
+		started: time.Now(),
+		now:     time.Now,
+	}
+}
+
+type dependency struct {
+	Status    string   `json:"status"`
+	LatencyMS *float64 `json:"latency_ms,omitempty"`
+	Error     string   `json:"error,omitempty"`
+	Circuit   string   `json:"circuit,omitempty"`
+}
+
+type readiness struct {
+	Status        string                `json:"status"`
+	Version       string                `json:"version"`
+	UptimeSeconds float64               `json:"uptime_seconds"`
+	CacheTier     string                `json:"cache_tier"`
+	Checks        map[string]dependency `json:"checks"`
+}
+
+// Liveness reports that the process is up and serving HTTP. It checks no
+// dependency, so that an outage elsewhere never gets the service restarted.
+func (h *HealthHandler) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status": StatusOK,
+	})
+}
+
+// Readiness checks every dependency. The service is degraded when it runs
+// without a cache, Redis is unreachable or upstream is unavailable, and down
+// (503) only when upstream is unavailable and there is no cache to serve from.
+func (h *HealthHandler) Readiness(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), readinessTimeout)
+	defer cancel()
+
+	var redis, upstream dependency
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		if h.cfg.Redis == nil {
+			redis = dependency{Status: "disabled"}
+			return
+		}
+		redis = run(ctx, h.cfg.Redis)
+	})
+	wg.Go(func() {
+		upstream = run(ctx, h.cfg.Upstream)
+	})
+	wg.Wait()
+
+	upstreamAvailable := upstream.Status == "up"
+	if h.cfg.Breaker != nil {
+		state := h.cfg.Breaker.State()
+		upstream.Circuit = state.String()
+		upstreamAvailable = upstreamAvailable && state != clients.BreakerOpen
+	}
+
+	status, code := StatusOK, http.StatusOK
+	switch {
+	case !upstreamAvailable && h.cfg.CacheTier == CacheTierNone:
+		status, code = StatusDown, http.StatusServiceUnavailable
+	case !upstreamAvailable, redis.Status == "down", h.cfg.CacheTier == CacheTierNone:
+		status = StatusDegraded
+	}
+
+	c.JSON(code, readiness{
+		Status:        status,
+		Version:       h.cfg.Version,
+		UptimeSeconds: h.now().Sub(h.started).Seconds(),
+		CacheTier:     h.cfg.CacheTier,
+		Checks: map[string]dependency{
+			"redis":    redis,
+			"upstream": upstream,
+		},
+	})
+}
+
+func run(ctx context.Context, check Check) dependency {
+	start := time.Now()
+	err := check(ctx)
+	latency := float64(time.Since(start).Microseconds()) / 1000
+
+	if err != nil {
+		return dependency{Status: "down", LatencyMS: &latency, Error: err.Error()}
+	}
+	return dependency{Status: "up", LatencyMS: &latency}
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"spacex-tracker/clients"
+	"spacex-tracker/models"
+)
+
+func up(ctx context.Context) error { return nil }
+
+func down(ctx context.Context) error { return errors.New("connection refused") }
+
+func readinessRequest(t *testing.T, cfg HealthConfig) (int, readiness) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	health := NewHealthHandler(cfg)
+
+	r := gin.New()
+	r.GET("/readyz", health.Readiness)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	var body readiness
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid body: %v", err)
+	}
+	return w.Code, body
+}
+
+func TestReadiness_AllDependenciesUp(t *testing.T) {
+	code, body := readinessRequest(t, HealthConfig{
+		Redis:     up,
+		Upstream:  up,
+		Breaker:   clients.NewCircuitBreaker(5, time.Minute),
+		CacheTier: CacheTierTiered,
+		Version:   "1.2.3",
+	})
+
+	if code != http.StatusOK || body.Status != StatusOK {
+		t.Fatalf("expected ok, got %d %+v", code, body)
+	}
+	if body.Version != "1.2.3" || body.CacheTier != CacheTierTiered {
+		t.Fatalf("unexpected metadata %+v", body)
+	}
+
+	redis := body.Checks["redis"]
+	if redis.Status != "up" || redis.LatencyMS == nil {
+		t.Fatalf("unexpected redis check %+v", redis)
+	}
+	if upstream := body.Checks["upstream"]; upstream.Status != "up" || upstream.Circuit != "closed" {
+		t.Fatalf("unexpected upstream check %+v", upstream)
+	}
+}
+
+func TestReadiness_Degraded(t *testing.T) {
+	for name, cfg := range map[string]HealthConfig{
+		"redis down":    {Redis: down, Upstream: up, CacheTier: CacheTierTiered},
+		"cacheless":     {Upstream: up, CacheTier: CacheTierNone},
+		"upstream down": {Upstream: down, CacheTier: CacheTierMemory},
+	} {
+		code, body := readinessRequest(t, cfg)
+		if code != http.StatusOK || body.Status != StatusDegraded {
+			t.Errorf("%s: expected degraded, got %d %+v", name, code, body)
+		}
+	}
+}
+
+func TestReadiness_DownWithoutUpstreamOrCache(t *testing.T) {
+	code, body := readinessRequest(t, HealthConfig{Upstream: down, CacheTier: CacheTierNone})
+
+	if code != http.StatusServiceUnavailable || body.Status != StatusDown {
+		t.Fatalf("expected down, got %d %+v", code, body)
+	}
+	if body.Checks["redis"].Status != "disabled" || body.Checks["upstream"].Error == "" {
+		t.Fatalf("unexpected checks %+v", body.Checks)
+	}
+}
+
+func TestReadiness_OpenCircuitMeansUpstreamUnavailable(t *testing.T) {
+	breaker := clients.NewCircuitBreaker(1, time.Minute)
+	clients.NewCircuitBreakerClient(&failingClient{}, breaker).GetNext(context.Background())
+
+	code, body := readinessRequest(t, HealthConfig{Upstream: up, Breaker: breaker, CacheTier: CacheTierMemory})
+	if code != http.StatusOK || body.Status != StatusDegraded || body.Checks["upstream"].Circuit != "open" {
+		t.Fatalf("expected degraded with an open circuit, got %d %+v", code, body)
+	}
+}
+
+func TestReadiness_WithoutBreaker(t *testing.T) {
+	code, body := readinessRequest(t, HealthConfig{Upstream: up, CacheTier: CacheTierMemory})
+
+	if code != http.StatusOK || body.Status != StatusOK {
+		t.Fatalf("expected ok, got %d %+v", code, body)
+	}
+	if upstream := body.Checks["upstream"]; upstream.Status != "up" || upstream.Circuit != "" {
+		t.Fatalf("expected no circuit to be reported, got %+v", upstream)
+	}
+}
+
+type failingClient struct {
+	clients.SpaceXClient
+}
+
+func (f *failingClient) GetNext(ctx context.Context) (*models.Launch, error) {
+	return nil, &clients.UpstreamStatusError{StatusCode: http.StatusBadGateway}
+}
+
+func TestLiveness(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/healthz", NewHealthHandler(HealthConfig{}).Liveness)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if w.Code != http.StatusOK || w.Body.String() != `{"status":"ok"}` {
+		t.Fatalf("unexpected response %d %s", w.Code, w.Body.String())
+	}
+}
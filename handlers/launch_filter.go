@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"spacex-tracker/services"
+)
+
+const dateOnly = "2006-01-02"
+
+// isObjectID reports whether id looks like a SpaceX API document ID
+// (a 24-character hex MongoDB ObjectId).
+func isObjectID(id string) bool {
+	if len(id) != 24 {
+		return false
+	}
+	for _, r := range id {
+		if !('0' <= r && r <= '9' || 'a' <= r && r <= 'f' || 'A' <= r && r <= 'F') {
+			return false
+		}
+	}
+	return true
+}
+
+// parseTime accepts an RFC 3339 timestamp or a YYYY-MM-DD date (UTC). A date
+// used as an upper bound covers the whole day.
+func parseTime(value string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse(dateOnly, value)
+	if err != nil {
+		return time.Time{}, errors.New("must be an RFC 3339 timestamp or a YYYY-MM-DD date")
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return t, nil
+}
+
+// parseLaunchFilter reads the from, to, success, name, rocket and launchpad
+// query parameters. Errors are meant to be shown to the caller as is.
+func parseLaunchFilter(c *gin.Context) (services.LaunchFilter, error) {
+	filter := services.LaunchFilter{
+		Name: c.Query("name"),
+	}
+
+	if value := c.Query("from"); value != "" {
+		from, err := parseTime(value, false)
+		if err != nil {
+			return filter, fmt.Errorf("invalid from: %w", err)
+		}
+		filter.From = from
+	}
+
+	if value := c.Query("to"); value != "" {
+		to, err := parseTime(value, true)
+		if err != nil {
+			return filter, fmt.Errorf("invalid to: %w", err)
+		}
+		filter.To = to
+	}
+
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.From.After(filter.To) {
+		return filter, errors.New("invalid date range: from is after to")
+	}
+
+	switch success := c.Query("success"); success {
+	case services.SuccessAny, services.SuccessTrue, services.SuccessFalse, services.SuccessUnknown:
+		filter.Success = success
+	default:
+		return filter, fmt.Errorf("invalid success %q: must be true, false or unknown", success)
+	}
+
+	for _, param := range []struct {
+		name  string
+		field *string
+	}{
+		{"rocket", &filter.Rocket},
+		{"launchpad", &filter.Launchpad},
+	} {
+		value := c.Query(param.name)
+		if value != "" && !isObjectID(value) {
+			return filter, fmt.Errorf("invalid %s %q: must be a 24-character hex ID", param.name, value)
+		}
+		// SpaceX IDs are lowercase, and the filter compares them exactly.
+		*param.field = strings.ToLower(value)
+	}
+
+	return filter, nil
+}
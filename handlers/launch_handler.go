@@ -1,9 +1,14 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"spacex-tracker/clients"
 	"spacex-tracker/services"
 )
 
@@ -18,11 +23,11 @@ func NewLaunchHandler(service services.LaunchService) *LaunchHandler {
 }
 
 func (h *LaunchHandler) GetNext(c *gin.Context) {
-	launch, err := h.service.GetNext(c.Request.Context())
+	ctx, info := services.WithCacheInfo(c.Request.Context())
+	launch, err := h.service.GetNext(ctx)
+	setCacheHeaders(c, info)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "failed to fetch next launch",
-		})
+		writeError(c, err, "failed to fetch next launch")
 		return
 	}
 
@@ -30,11 +35,11 @@ func (h *LaunchHandler) GetNext(c *gin.Context) {
 }
 
 func (h *LaunchHandler) GetLatest(c *gin.Context) {
-	launch, err := h.service.GetLatest(c.Request.Context())
+	ctx, info := services.WithCacheInfo(c.Request.Context())
+	launch, err := h.service.GetLatest(ctx)
+	setCacheHeaders(c, info)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "failed to fetch latest launch",
-		})
+		writeError(c, err, "failed to fetch latest launch")
 		return
 	}
 
@@ -42,27 +47,105 @@ func (h *LaunchHandler) GetLatest(c *gin.Context) {
 }
 
 func (h *LaunchHandler) GetUpcoming(c *gin.Context) {
-	launches, err := h.service.GetUpcoming(c.Request.Context())
+	filter, page, err := parseListQuery(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "failed to fetch upcoming launches",
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, launches)
+	ctx, info := services.WithCacheInfo(c.Request.Context())
+	launches, err := h.service.GetUpcoming(ctx, filter)
+	setCacheHeaders(c, info)
+	if err != nil {
+		writeError(c, err, "failed to fetch upcoming launches")
+		return
+	}
+
+	writePage(c, launches, page)
 }
 
 func (h *LaunchHandler) GetPast(c *gin.Context) {
 	sortOrder := c.DefaultQuery("sort", "desc")
+	filter, page, err := parseListQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
 
-	launches, err := h.service.GetPast(c.Request.Context(), sortOrder)
+	ctx, info := services.WithCacheInfo(c.Request.Context())
+	launches, err := h.service.GetPast(ctx, sortOrder, filter)
+	setCacheHeaders(c, info)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "failed to fetch past launches",
+		writeError(c, err, "failed to fetch past launches")
+		return
+	}
+
+	writePage(c, launches, page)
+}
+
+func (h *LaunchHandler) GetByID(c *gin.Context) {
+	id := c.Param("id")
+	if !isObjectID(id) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("invalid launch id %q: must be a 24-character hex ID", id),
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, launches)
-}
\ No newline at end of file
+	ctx, info := services.WithCacheInfo(c.Request.Context())
+	launch, err := h.service.GetByID(ctx, strings.ToLower(id))
+	setCacheHeaders(c, info)
+	if err != nil {
+		writeLaunchError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, launch)
+}
+
+func (h *LaunchHandler) GetByFlightNumber(c *gin.Context) {
+	value := c.Param("number")
+	flightNumber, err := strconv.Atoi(value)
+	if err != nil || flightNumber < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("invalid flight number %q: must be a positive integer", value),
+		})
+		return
+	}
+
+	ctx, info := services.WithCacheInfo(c.Request.Context())
+	launch, err := h.service.GetByFlightNumber(ctx, flightNumber)
+	setCacheHeaders(c, info)
+	if err != nil {
+		writeLaunchError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, launch)
+}
+
+// writeLaunchError answers a single-launch lookup, telling a missing launch
+// apart from a failed fetch.
+func writeLaunchError(c *gin.Context, err error) {
+	if errors.Is(err, clients.ErrNotFound) {
+		writeError(c, err, "launch not found")
+		return
+	}
+	writeError(c, err, "failed to fetch launch")
+}
+
+// parseListQuery reads the filter and pagination parameters shared by the
+// list endpoints.
+func parseListQuery(c *gin.Context) (services.LaunchFilter, pageRequest, error) {
+	filter, err := parseLaunchFilter(c)
+	if err != nil {
+		return filter, pageRequest{}, err
+	}
+
+	page, err := parsePage(c)
+	return filter, page, err
+}
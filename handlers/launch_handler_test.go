@@ -3,11 +3,15 @@ package handlers
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"spacex-tracker/clients"
 	"spacex-tracker/models"
+	"spacex-tracker/services"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -24,9 +28,18 @@ type mockLaunchService struct {
 
 	pastResult []models.Launch
 	pastErr    error
+
+	filter services.LaunchFilter
+
+	launchResult *models.Launch
+	launchErr    error
+	lookups      []string
+
+	cacheStatus services.CacheStatus
 }
 
 func (m *mockLaunchService) GetNext(ctx context.Context) (*models.Launch, error) {
+	services.SetCacheStatus(ctx, m.cacheStatus)
 	return m.nextResult, m.nextErr
 }
 
@@ -34,14 +47,26 @@ func (m *mockLaunchService) GetLatest(ctx context.Context) (*models.Launch, erro
 	return m.latestResult, m.latestErr
 }
 
-func (m *mockLaunchService) GetUpcoming(ctx context.Context) ([]models.Launch, error) {
+func (m *mockLaunchService) GetUpcoming(ctx context.Context, filter services.LaunchFilter) ([]models.Launch, error) {
+	m.filter = filter
 	return m.upcomingResult, m.upcomingErr
 }
 
-func (m *mockLaunchService) GetPast(ctx context.Context, sortOrder string) ([]models.Launch, error) {
+func (m *mockLaunchService) GetPast(ctx context.Context, sortOrder string, filter services.LaunchFilter) ([]models.Launch, error) {
+	m.filter = filter
 	return m.pastResult, m.pastErr
 }
 
+func (m *mockLaunchService) GetByID(ctx context.Context, id string) (*models.Launch, error) {
+	m.lookups = append(m.lookups, "id "+id)
+	return m.launchResult, m.launchErr
+}
+
+func (m *mockLaunchService) GetByFlightNumber(ctx context.Context, flightNumber int) (*models.Launch, error) {
+	m.lookups = append(m.lookups, fmt.Sprintf("flight number %d", flightNumber))
+	return m.launchResult, m.launchErr
+}
+
 func setupRouter(service *mockLaunchService) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 
@@ -56,6 +81,8 @@ func setupRouter(service *mockLaunchService) *gin.Engine {
 			launches.GET("/latest", handler.GetLatest)
 			launches.GET("/upcoming", handler.GetUpcoming)
 			launches.GET("/past", handler.GetPast)
+			launches.GET("/flight/:number", handler.GetByFlightNumber)
+			launches.GET("/:id", handler.GetByID)
 		}
 	}
 
@@ -147,4 +174,233 @@ func TestGetPast_WithQuery(t *testing.T) {
 	if w.Code != http.StatusOK {
 		t.Fatalf("expected 200, got %d", w.Code)
 	}
-}
\ No newline at end of file
+}
+
+func TestGetLatest_UpstreamErrorMapping(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"not found", &clients.UpstreamStatusError{StatusCode: http.StatusNotFound}, http.StatusNotFound},
+		{"rate limited", &clients.UpstreamStatusError{StatusCode: http.StatusTooManyRequests}, http.StatusTooManyRequests},
+		{"upstream 503", &clients.UpstreamStatusError{StatusCode: http.StatusServiceUnavailable}, http.StatusBadGateway},
+		{"malformed payload", fmt.Errorf("%w: bad json", clients.ErrMalformedPayload), http.StatusBadGateway},
+		{"timeout", fmt.Errorf("%w: deadline", clients.ErrTimeout), http.StatusGatewayTimeout},
+		{"circuit open", clients.ErrCircuitOpen, http.StatusServiceUnavailable},
+		{"unknown", errors.New("boom"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := setupRouter(&mockLaunchService{latestErr: tc.err})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/launches/latest", nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.expected {
+				t.Fatalf("expected %d, got %d", tc.expected, w.Code)
+			}
+		})
+	}
+}
+
+func TestGetNext_CacheHeaders(t *testing.T) {
+	cases := []struct {
+		status  services.CacheStatus
+		xCache  string
+		warning bool
+	}{
+		{"", "", false},
+		{services.CacheHit, "HIT", false},
+		{services.CacheMiss, "MISS", false},
+		{services.CacheStale, "STALE", true},
+	}
+
+	for _, tc := range cases {
+		router := setupRouter(&mockLaunchService{
+			nextResult:  &models.Launch{Name: "Falcon 9"},
+			cacheStatus: tc.status,
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/launches/next", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		if got := w.Header().Get("X-Cache"); got != tc.xCache {
+			t.Errorf("status %q: expected X-Cache %q, got %q", tc.status, tc.xCache, got)
+		}
+
+		if hasWarning := w.Header().Get("Warning") != ""; hasWarning != tc.warning {
+			t.Errorf("status %q: expected Warning header %v, got %v", tc.status, tc.warning, hasWarning)
+		}
+	}
+}
+
+func TestGetPast_PassesFilter(t *testing.T) {
+	mockSvc := &mockLaunchService{}
+	router := setupRouter(mockSvc)
+
+	req := httptest.NewRequest(
+		http.MethodGet,
+		"/api/v1/launches/past?from=2020-01-01&to=2020-12-31&success=unknown&name=starlink&rocket=5e9d0d95eda69973a809d1ec",
+		nil,
+	)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	want := services.LaunchFilter{
+		From:    time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:      time.Date(2020, 12, 31, 23, 59, 59, int(time.Second-time.Nanosecond), time.UTC),
+		Success: services.SuccessUnknown,
+		Name:    "starlink",
+		Rocket:  "5e9d0d95eda69973a809d1ec",
+	}
+	if mockSvc.filter != want {
+		t.Fatalf("expected filter %+v, got %+v", want, mockSvc.filter)
+	}
+}
+
+func TestGetUpcoming_LowercasesFilterIDs(t *testing.T) {
+	mockSvc := &mockLaunchService{}
+	router := setupRouter(mockSvc)
+
+	req := httptest.NewRequest(
+		http.MethodGet,
+		"/api/v1/launches/upcoming?rocket=5E9D0D95EDA69973A809D1EC&launchpad=5E9E4501F509094BA4566F84",
+		nil,
+	)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if mockSvc.filter.Rocket != "5e9d0d95eda69973a809d1ec" || mockSvc.filter.Launchpad != "5e9e4501f509094ba4566f84" {
+		t.Fatalf("expected lowercase IDs, got %+v", mockSvc.filter)
+	}
+}
+
+func TestListEndpoints_InvalidFilter(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"from=yesterday", "invalid from"},
+		{"to=2020-13-01", "invalid to"},
+		{"from=2021-01-01&to=2020-01-01", "from is after to"},
+		{"success=maybe", "invalid success"},
+		{"rocket=falcon9", "invalid rocket"},
+		{"launchpad=5e9e4501f509094ba4566f8", "invalid launchpad"},
+	}
+
+	for _, path := range []string{"/api/v1/launches/past", "/api/v1/launches/upcoming"} {
+		for _, tt := range tests {
+			mockSvc := &mockLaunchService{}
+			router := setupRouter(mockSvc)
+
+			req := httptest.NewRequest(http.MethodGet, path+"?"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("%s?%s: expected 400, got %d", path, tt.query, w.Code)
+			}
+			if !strings.Contains(w.Body.String(), tt.want) {
+				t.Fatalf("%s?%s: expected error containing %q, got %s", path, tt.query, tt.want, w.Body.String())
+			}
+		}
+	}
+}
+
+func TestGetByID_Success(t *testing.T) {
+	mockSvc := &mockLaunchService{
+		launchResult: &models.Launch{Id: "5eb87cd9ffd86e000604b32a", Name: "FalconSat"},
+	}
+
+	router := setupRouter(mockSvc)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/launches/5EB87CD9FFD86E000604B32A", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "FalconSat") {
+		t.Fatalf("unexpected response %d %s", w.Code, w.Body.String())
+	}
+
+	if len(mockSvc.lookups) != 1 || mockSvc.lookups[0] != "id 5eb87cd9ffd86e000604b32a" {
+		t.Fatalf("expected a lookup by the lower-cased ID, got %v", mockSvc.lookups)
+	}
+}
+
+func TestGetByID_NotFound(t *testing.T) {
+	mockSvc := &mockLaunchService{
+		launchErr: &services.LaunchNotFoundError{Lookup: "id 5eb87cd9ffd86e000604b32a"},
+	}
+
+	router := setupRouter(mockSvc)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/launches/5eb87cd9ffd86e000604b32a", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound || w.Body.String() != `{"error":"launch not found"}` {
+		t.Fatalf("unexpected response %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestGetByFlightNumber_Success(t *testing.T) {
+	mockSvc := &mockLaunchService{
+		launchResult: &models.Launch{Name: "FalconSat", FlightNumber: 1},
+	}
+
+	router := setupRouter(mockSvc)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/launches/flight/1", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK || len(mockSvc.lookups) != 1 || mockSvc.lookups[0] != "flight number 1" {
+		t.Fatalf("unexpected response %d %s (lookups %v)", w.Code, w.Body.String(), mockSvc.lookups)
+	}
+}
+
+func TestSingleLaunch_MalformedIDsNeverReachTheService(t *testing.T) {
+	mockSvc := &mockLaunchService{}
+	router := setupRouter(mockSvc)
+
+	for _, path := range []string{
+		"/api/v1/launches/falconsat",
+		"/api/v1/launches/5eb87cd9ffd86e000604b32",
+		"/api/v1/launches/5eb87cd9ffd86e000604b32z",
+		"/api/v1/launches/flight/0",
+		"/api/v1/launches/flight/-3",
+		"/api/v1/launches/flight/first",
+	} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %d", path, w.Code)
+		}
+	}
+
+	if len(mockSvc.lookups) != 0 {
+		t.Fatalf("expected no service calls, got %v", mockSvc.lookups)
+	}
+}
@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"spacex-tracker/models"
+)
+
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 200
+)
+
+// launchPage is the envelope returned by the list endpoints. Cursors are
+// null on the first and last page.
+type launchPage struct {
+	Items      []models.Launch `json:"items"`
+	Total      int             `json:"total"`
+	NextCursor *string         `json:"next_cursor"`
+	PrevCursor *string         `json:"prev_cursor"`
+}
+
+// pageCursor points at the first launch of a page. The ID keeps the page
+// anchored when launches are added or removed in front of it; the offset is
+// the fallback when that launch is no longer in the list.
+type pageCursor struct {
+	ID     string `json:"id"`
+	Offset int    `json:"offset"`
+}
+
+func (p pageCursor) encode() string {
+	data, _ := json.Marshal(p)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(s string) (pageCursor, error) {
+	var cursor pageCursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cursor, err
+	}
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return cursor, err
+	}
+	if cursor.Offset < 0 {
+		return cursor, errors.New("negative offset")
+	}
+	return cursor, nil
+}
+
+type pageRequest struct {
+	limit  int
+	offset int
+	cursor *pageCursor
+}
+
+// parsePage reads the limit, offset and cursor query parameters. A cursor
+// replaces offset, so the two cannot be combined.
+func parsePage(c *gin.Context) (pageRequest, error) {
+	page := pageRequest{limit: defaultPageLimit}
+
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxPageLimit {
+			return page, fmt.Errorf("invalid limit %q: must be an integer between 1 and %d", value, maxPageLimit)
+		}
+		page.limit = limit
+	}
+
+	offset, cursor := c.Query("offset"), c.Query("cursor")
+	if offset != "" && cursor != "" {
+		return page, errors.New("invalid pagination: offset and cursor cannot be combined")
+	}
+
+	if offset != "" {
+		n, err := strconv.Atoi(offset)
+		if err != nil || n < 0 {
+			return page, fmt.Errorf("invalid offset %q: must be a non-negative integer", offset)
+		}
+		page.offset = n
+	}
+
+	if cursor != "" {
+		decoded, err := decodeCursor(cursor)
+		if err != nil {
+			return page, errors.New("invalid cursor")
+		}
+		page.cursor = &decoded
+	}
+
+	return page, nil
+}
+
+// start resolves the request to an index into launches.
+func (p pageRequest) start(launches []models.Launch) int {
+	if p.cursor == nil {
+		return min(p.offset, len(launches))
+	}
+
+	if id := p.cursor.ID; id != "" {
+		if p.cursor.Offset < len(launches) && launches[p.cursor.Offset].Id == id {
+			return p.cursor.Offset
+		}
+		for i, launch := range launches {
+			if launch.Id == id {
+				return i
+			}
+		}
+	}
+	return min(p.cursor.Offset, len(launches))
+}
+
+// writePage responds with one page of launches and the matching RFC 8288
+// Link header. Items are sliced from launches, which is only read.
+func writePage(c *gin.Context, launches []models.Launch, req pageRequest) {
+	start := req.start(launches)
+	end := min(start+req.limit, len(launches))
+
+	page := launchPage{
+		Items: launches[start:end],
+		Total: len(launches),
+	}
+	if page.Items == nil {
+		page.Items = []models.Launch{}
+	}
+
+	var links []string
+	if end < len(launches) {
+		next := pageCursor{ID: launches[end].Id, Offset: end}.encode()
+		page.NextCursor = &next
+		links = append(links, pageLink(c, next, req.limit, "next"))
+	}
+	if start > 0 {
+		offset := max(start-req.limit, 0)
+		prev := pageCursor{ID: launches[offset].Id, Offset: offset}.encode()
+		page.PrevCursor = &prev
+		links = append(links, pageLink(c, prev, req.limit, "prev"))
+	}
+	if len(links) > 0 {
+		c.Header("Link", strings.Join(links, ", "))
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// pageLink builds a link to the current URL with its filters kept and the
+// position replaced by cursor.
+func pageLink(c *gin.Context, cursor string, limit int, rel string) string {
+	target := *c.Request.URL
+	query := target.Query()
+	query.Del("offset")
+	query.Set("cursor", cursor)
+	query.Set("limit", strconv.Itoa(limit))
+	target.RawQuery = query.Encode()
+
+	return fmt.Sprintf(`<%s>; rel="%s"`, target.RequestURI(), rel)
+}
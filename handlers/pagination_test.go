@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"spacex-tracker/clients"
+	"spacex-tracker/models"
+	"spacex-tracker/services"
+	"spacex-tracker/services/cache"
+)
+
+func numberedLaunches(n int) []models.Launch {
+	launches := make([]models.Launch, n)
+	for i := range launches {
+		launches[i] = models.Launch{Id: fmt.Sprintf("launch-%d", i), Name: fmt.Sprintf("Launch %d", i)}
+	}
+	return launches
+}
+
+func getPage(t *testing.T, router *gin.Engine, target string) (*httptest.ResponseRecorder, launchPage) {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+
+	var page launchPage
+	if w.Code == http.StatusOK {
+		if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+			t.Fatalf("invalid page %s: %v", w.Body.String(), err)
+		}
+	}
+	return w, page
+}
+
+func pageIDs(page launchPage) []string {
+	result := make([]string, len(page.Items))
+	for i, launch := range page.Items {
+		result[i] = launch.Id
+	}
+	return result
+}
+
+func TestPagination_LimitOffset(t *testing.T) {
+	router := setupRouter(&mockLaunchService{pastResult: numberedLaunches(5)})
+
+	w, page := getPage(t, router, "/api/v1/launches/past?limit=2&offset=2")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	if got := pageIDs(page); !reflect.DeepEqual(got, []string{"launch-2", "launch-3"}) || page.Total != 5 {
+		t.Fatalf("unexpected page %v (total %d)", got, page.Total)
+	}
+	if page.NextCursor == nil || page.PrevCursor == nil {
+		t.Fatalf("expected both cursors, got %+v", page)
+	}
+
+	link := w.Header().Get("Link")
+	if !strings.Contains(link, `rel="next"`) || !strings.Contains(link, `rel="prev"`) {
+		t.Fatalf("unexpected Link header %q", link)
+	}
+}
+
+func TestPagination_FollowsCursors(t *testing.T) {
+	router := setupRouter(&mockLaunchService{upcomingResult: numberedLaunches(5)})
+
+	var seen []string
+	target := "/api/v1/launches/upcoming?limit=2"
+	for target != "" {
+		_, page := getPage(t, router, target)
+		seen = append(seen, pageIDs(page)...)
+
+		target = ""
+		if page.NextCursor != nil {
+			target = "/api/v1/launches/upcoming?limit=2&cursor=" + url.QueryEscape(*page.NextCursor)
+		}
+	}
+
+	if want := pageIDs(launchPage{Items: numberedLaunches(5)}); !reflect.DeepEqual(seen, want) {
+		t.Fatalf("expected %v, got %v", want, seen)
+	}
+}
+
+func TestPagination_CursorSurvivesNewLaunches(t *testing.T) {
+	mockSvc := &mockLaunchService{pastResult: numberedLaunches(4)}
+	router := setupRouter(mockSvc)
+
+	_, first := getPage(t, router, "/api/v1/launches/past?limit=2")
+
+	// A new launch lands at the head of the list between requests.
+	mockSvc.pastResult = append([]models.Launch{{Id: "launch-new"}}, numberedLaunches(4)...)
+
+	_, second := getPage(t, router, "/api/v1/launches/past?limit=2&cursor="+url.QueryEscape(*first.NextCursor))
+	if got := pageIDs(second); !reflect.DeepEqual(got, []string{"launch-2", "launch-3"}) {
+		t.Fatalf("expected the page after launch-1, got %v", got)
+	}
+}
+
+func TestPagination_EdgePages(t *testing.T) {
+	router := setupRouter(&mockLaunchService{pastResult: numberedLaunches(3)})
+
+	w, page := getPage(t, router, "/api/v1/launches/past")
+	if len(page.Items) != 3 || page.NextCursor != nil || page.PrevCursor != nil || w.Header().Get("Link") != "" {
+		t.Fatalf("expected a single page without links, got %+v (Link %q)", page, w.Header().Get("Link"))
+	}
+
+	_, page = getPage(t, router, "/api/v1/launches/past?offset=10")
+	if page.Items == nil || len(page.Items) != 0 || page.Total != 3 || page.PrevCursor == nil {
+		t.Fatalf("expected an empty page past the end, got %+v", page)
+	}
+}
+
+func TestPagination_LinkKeepsFilters(t *testing.T) {
+	router := setupRouter(&mockLaunchService{pastResult: numberedLaunches(3)})
+
+	w, _ := getPage(t, router, "/api/v1/launches/past?sort=asc&name=launch&limit=1&offset=1")
+
+	for _, part := range strings.Split(w.Header().Get("Link"), ", ") {
+		target := strings.TrimPrefix(strings.SplitN(part, ">", 2)[0], "<")
+		link, err := url.Parse(target)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		query := link.Query()
+		if link.Path != "/api/v1/launches/past" || query.Get("sort") != "asc" || query.Get("name") != "launch" ||
+			query.Get("limit") != "1" || query.Has("offset") || query.Get("cursor") == "" {
+			t.Fatalf("unexpected link %q", target)
+		}
+	}
+}
+
+func TestPagination_InvalidParams(t *testing.T) {
+	router := setupRouter(&mockLaunchService{})
+
+	for _, query := range []string{
+		"limit=0",
+		"limit=201",
+		"limit=ten",
+		"offset=-1",
+		"cursor=not-a-cursor",
+		"offset=1&cursor=eyJpZCI6IiIsIm9mZnNldCI6MX0",
+	} {
+		w, _ := getPage(t, router, "/api/v1/launches/past?"+query)
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %d", query, w.Code)
+		}
+	}
+}
+
+type countingClient struct {
+	clients.SpaceXClient
+	calls atomic.Int32
+}
+
+func (c *countingClient) GetPast(ctx context.Context) ([]models.Launch, error) {
+	c.calls.Add(1)
+	return numberedLaunches(7), nil
+}
+
+func TestPagination_PagesShareOneCachedList(t *testing.T) {
+	client := &countingClient{}
+	service := services.NewCachedLaunchService(services.NewBaseLaunchService(client), cache.NewMemoryCache(100), time.Minute)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/v1/launches/past", NewLaunchHandler(service).GetPast)
+
+	for _, query := range []string{"limit=3", "limit=3&offset=3", "limit=3&offset=6", "limit=5&name=1"} {
+		if w, _ := getPage(t, router, "/api/v1/launches/past?"+query); w.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d", query, w.Code)
+		}
+	}
+
+	if calls := client.calls.Load(); calls != 1 {
+		t.Fatalf("expected one upstream call, got %d", calls)
+	}
+}
@@ -0,0 +1,115 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newTestRouter(t *testing.T, buf *bytes.Buffer) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	logger, err := New(buf, "debug")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := gin.New()
+	r.Use(Middleware(logger), Recovery())
+	r.GET("/items/:id", func(c *gin.Context) {
+		FromContext(c.Request.Context()).Info("handling")
+		c.Status(http.StatusNoContent)
+	})
+	r.GET("/panic", func(c *gin.Context) { panic("boom") })
+	return r
+}
+
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var lines []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("log line is not JSON: %q", line)
+		}
+		lines = append(lines, entry)
+	}
+	return lines
+}
+
+func TestMiddleware_PropagatesIncomingRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	r := newTestRouter(t, &buf)
+
+	req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+	req.Header.Set(RequestIDHeader, "abc-123")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if got := w.Header().Get(RequestIDHeader); got != "abc-123" {
+		t.Fatalf("expected the request ID to be echoed, got %q", got)
+	}
+
+	lines := decodeLines(t, &buf)
+	if len(lines) != 2 {
+		t.Fatalf("expected a handler line and a request line, got %v", lines)
+	}
+	for _, line := range lines {
+		if line["request_id"] != "abc-123" {
+			t.Fatalf("missing request_id in %v", line)
+		}
+	}
+
+	request := lines[1]
+	if request["msg"] != "request" || request["route"] != "/items/:id" || request["status"] != float64(204) {
+		t.Fatalf("unexpected request line %v", request)
+	}
+}
+
+func TestMiddleware_GeneratesRequestID(t *testing.T) {
+	for _, incoming := range []string{"", "has spaces", strings.Repeat("x", maxRequestIDLength+1)} {
+		var buf bytes.Buffer
+		r := newTestRouter(t, &buf)
+
+		req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+		req.Header.Set(RequestIDHeader, incoming)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if got := w.Header().Get(RequestIDHeader); len(got) != 32 || got == incoming {
+			t.Fatalf("incoming %q: expected a generated ID, got %q", incoming, got)
+		}
+	}
+}
+
+func TestRecovery_LogsPanicsAsErrors(t *testing.T) {
+	var buf bytes.Buffer
+	r := newTestRouter(t, &buf)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/panic", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", w.Code)
+	}
+
+	lines := decodeLines(t, &buf)
+	if lines[0]["level"] != "ERROR" || lines[0]["panic"] != "boom" {
+		t.Fatalf("unexpected panic line %v", lines[0])
+	}
+	if lines[1]["level"] != "ERROR" || lines[1]["status"] != float64(500) {
+		t.Fatalf("unexpected request line %v", lines[1])
+	}
+}
+
+func TestNew_RejectsUnknownLevel(t *testing.T) {
+	if _, err := New(&bytes.Buffer{}, "verbose"); err == nil {
+		t.Fatal("expected an error for an unknown level")
+	}
+}
@@ -2,26 +2,58 @@ package main
 
 import (
 	"context"
-	"log"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
 	"spacex-tracker/clients"
 	"spacex-tracker/configs"
 	"spacex-tracker/handlers"
+	"spacex-tracker/logging"
+	"spacex-tracker/metrics"
 	"spacex-tracker/services"
 	"spacex-tracker/services/cache"
+	"spacex-tracker/tracing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/redis/go-redis/v9"
 )
 
+// version is set at build time with -ldflags "-X main.version=...".
+var version = "dev"
+
+// fatal logs err and exits.
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
 func main() {
+	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, nil)))
+
 	// Load configs
 	cfg, err := configs.Load()
 	if (err != nil) {
-		log.Fatal(err)
+		fatal("failed to load config", err)
 	}
 
-	log.Println("Using Redis URL:", cfg.RedisURL)
+	logger, err := logging.New(os.Stdout, cfg.LogLevel)
+	if err != nil {
+		fatal("failed to set up logging", err)
+	}
+	slog.SetDefault(logger)
+
+	// Cancelled on SIGINT/SIGTERM; background workers stop with it.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	var workers sync.WaitGroup
+
+	slog.Info("using Redis", "redis_url", cfg.RedisURL)
 	
 	var rdb *redis.Client
 	opt, err := redis.ParseURL(cfg.RedisURL)
@@ -29,37 +61,133 @@ func main() {
 		candidate := redis.NewClient(opt)
 
 		if err := candidate.Ping(context.Background()).Err(); err != nil {
-			log.Printf("Redis unreachable: %v. Running cacheless.", err)
+			slog.Warn("Redis unreachable, using the in-memory cache only", "error", err)
+			candidate.Close()
 		} else {
 			rdb = candidate
-			defer rdb.Close()
-			log.Println("Redis connected successfully")
+			slog.Info("Redis connected successfully")
 		}
 	} else {
-		log.Println("Invalid Redis URL, running cacheless")
+		slog.Warn("invalid Redis URL, using the in-memory cache only", "error", err)
 	}
 	
-	client := clients.NewSpaceXClient(cfg)
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.TracingExporter)
+	if err != nil {
+		fatal("failed to set up tracing", err)
+	}
+
+	registry := metrics.NewRegistry()
+
+	breaker := clients.NewCircuitBreaker(cfg.BreakerFailureThreshold, cfg.BreakerCooldown)
+	metrics.RegisterBreaker(registry, breaker)
+	client := tracing.NewTracedClient(
+		clients.NewCircuitBreakerClient(metrics.NewInstrumentedClient(clients.NewSpaceXClient(cfg), registry), breaker),
+	)
 	base := services.NewBaseLaunchService(client)
 	var service services.LaunchService
 	
+	var store, shared cache.Cache
+	cacheTier := handlers.CacheTierNone
+	if cfg.MemoryCacheSize > 0 {
+		store = cache.NewMemoryCache(cfg.MemoryCacheSize)
+		cacheTier = handlers.CacheTierMemory
+	}
 	if rdb != nil {
 		redisCache := cache.NewRedisCache(rdb)
-		service = services.NewCachedLaunchService(base, redisCache, cfg.CacheTTL)
+		if local := store; local != nil {
+			cacheTier = handlers.CacheTierTiered
+			// Purges on any replica must also reach every other replica's memory tier.
+			invalidator := cache.NewInvalidator(rdb, local)
+			workers.Go(func() { invalidator.Run(ctx) })
+
+			store = cache.NewBroadcastingCache(cache.NewTieredCache(local, redisCache, cfg.MemoryCacheTTL), invalidator)
+			// The warmer reads freshness from Redis, which other replicas may
+			// have refreshed since this replica's local copy was taken.
+			shared = tracing.NewTracedCache(redisCache)
+		} else {
+			store = redisCache
+			cacheTier = handlers.CacheTierRedis
+		}
+	}
+
+	if store != nil {
+		store = tracing.NewTracedCache(store)
+
+		compression, err := services.ParseCompression(cfg.CacheCompression)
+		if err != nil {
+			fatal("invalid cache compression", err)
+		}
+
+		service = services.NewCachedLaunchService(base, store, cfg.CacheTTL,
+			services.WithTTLPolicy(services.TTLPolicy{
+				Next:     cfg.CacheTTLNext,
+				Latest:   cfg.CacheTTLLatest,
+				Upcoming: cfg.CacheTTLUpcoming,
+				Past:     cfg.CacheTTLPast,
+				Adaptive: cfg.CacheAdaptiveTTL,
+			}),
+			services.WithStaleTTL(cfg.CacheStaleTTL),
+			services.WithNegativeTTL(cfg.CacheNegativeTTL),
+			services.WithCompression(compression),
+			services.WithMetrics(metrics.NewCacheMetrics(registry)),
+			services.WithSharedCache(shared),
+			// Stale-entry refreshes are waited for with the workers, before Redis is closed.
+			services.WithBackgroundGroup(&workers),
+		)
 	} else {
+		slog.Warn("no cache tier configured, running cacheless")
 		service = base
 	}
 
-	handler := handlers.NewLaunchHandler(service)
+	if warmable, ok := service.(services.Warmable); ok && cfg.WarmerInterval > 0 {
+		var locker cache.Locker
+		if rdb != nil {
+			locker = cache.NewRedisLocker(rdb)
+		}
+		warmer := services.NewWarmer(warmable, locker, cfg.WarmerInterval, cfg.WarmerJitter)
+		workers.Go(func() { warmer.Run(ctx) })
+	}
 
-	r := gin.Default()
+	handler := handlers.NewLaunchHandler(metrics.NewInstrumentedService(tracing.NewTracedService(service), registry))
+
+	// gin's debug output is plain text and would break the JSON logs, so it
+	// stays off unless GIN_MODE asks for it, and then goes through slog.
+	if os.Getenv(gin.EnvGinMode) == "" {
+		gin.SetMode(gin.ReleaseMode)
+	}
+	gin.DebugPrintRouteFunc = func(method, path, handler string, handlers int) {
+		slog.Debug("route registered", "method", method, "path", path, "handler", handler, "handlers", handlers)
+	}
+	gin.DebugPrintFunc = func(format string, values ...any) {
+		slog.Debug(strings.TrimSpace(fmt.Sprintf(format, values...)))
+	}
+
+	r := gin.New()
+	r.Use(tracing.Middleware(), logging.Middleware(logger), logging.Recovery(), metrics.Middleware(registry))
 
 	r.GET("/health", func (c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
 			"status": "ok",
+			"upstream_circuit": breaker.State().String(),
 		})
 	})
 
+	var redisCheck handlers.Check
+	if rdb != nil {
+		redisCheck = func(ctx context.Context) error { return rdb.Ping(ctx).Err() }
+	}
+	health := handlers.NewHealthHandler(handlers.HealthConfig{
+		Redis:     redisCheck,
+		Upstream:  clients.NewProbe(cfg),
+		Breaker:   breaker,
+		CacheTier: cacheTier,
+		Version:   version,
+	})
+	r.GET("/healthz", health.Liveness)
+	r.GET("/readyz", health.Readiness)
+
+	r.GET("/metrics", gin.WrapH(metrics.Handler(registry)))
+
 	v1 := r.Group("/api/v1")
 	{
 		launches := v1.Group("/launches")
@@ -68,8 +196,87 @@ func main() {
             launches.GET("/latest", handler.GetLatest)
             launches.GET("/upcoming", handler.GetUpcoming)
             launches.GET("/past", handler.GetPast)
+            launches.GET("/flight/:number", handler.GetByFlightNumber)
+            launches.GET("/:id", handler.GetByID)
+		}
+	}
+
+	if refresher, ok := service.(services.Refresher); ok && cfg.AdminToken != "" {
+		admin := handlers.NewAdminHandler(store, refresher)
+
+		adminGroup := r.Group("/admin", handlers.RequireToken(cfg.AdminToken))
+		{
+			adminGroup.GET("/cache", admin.ListKeys)
+			adminGroup.DELETE("/cache", admin.PurgeAll)
+			adminGroup.DELETE("/cache/:key", admin.PurgeKey)
+			adminGroup.POST("/cache/refresh/:endpoint", admin.Refresh)
 		}
+	} else {
+		slog.Info("ADMIN_TOKEN not set or running cacheless, admin endpoints disabled")
+	}
+
+	server := &http.Server{
+		Addr:              cfg.HTTPAddr,
+		Handler:           r,
+		ReadTimeout:       cfg.HTTPReadTimeout,
+		ReadHeaderTimeout: cfg.HTTPReadHeaderTimeout,
+		WriteTimeout:      cfg.HTTPWriteTimeout,
+		IdleTimeout:       cfg.HTTPIdleTimeout,
+		MaxHeaderBytes:    cfg.HTTPMaxHeaderBytes,
 	}
 
-	r.Run(":8080")
+	serveErr := make(chan error, 1)
+	go func() {
+		slog.Info("listening", "addr", server.Addr, "tls", cfg.TLSCertFile != "")
+		if cfg.TLSCertFile != "" {
+			serveErr <- server.ListenAndServeTLS(cfg.TLSCertFile, cfg.TLSKeyFile)
+		} else {
+			serveErr <- server.ListenAndServe()
+		}
+	}()
+
+	exitCode := 0
+	select {
+	case <-ctx.Done():
+		slog.Info("shutting down", "grace_period", cfg.ShutdownTimeout.String())
+	case err := <-serveErr:
+		slog.Error("server stopped", "error", err)
+		exitCode = 1
+	}
+	stop()
+
+	// Everything below shares one grace period: drain in-flight requests,
+	// wait for the background workers, then release Redis and flush traces.
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		slog.Error("failed to drain in-flight requests", "error", err)
+		exitCode = 1
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-shutdownCtx.Done():
+		slog.Warn("background workers did not stop within the grace period")
+	}
+
+	if rdb != nil {
+		if err := rdb.Close(); err != nil {
+			slog.Error("failed to close Redis", "error", err)
+		}
+	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		slog.Error("failed to flush traces", "error", err)
+	}
+
+	slog.Info("shutdown complete")
+	if exitCode != 0 {
+		os.Exit(exitCode)
+	}
 }
\ No newline at end of file
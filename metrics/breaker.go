@@ -0,0 +1,24 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"spacex-tracker/clients"
+)
+
+// RegisterBreaker exposes the state of breaker as one gauge per state, set to
+// 1 for the current state and 0 for the others.
+func RegisterBreaker(registry prometheus.Registerer, breaker *clients.CircuitBreaker) {
+	for _, state := range []clients.BreakerState{clients.BreakerClosed, clients.BreakerOpen, clients.BreakerHalfOpen} {
+		registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Name:        "upstream_circuit_state",
+			Help:        "Circuit breaker state for the SpaceX API: 1 for the current state, 0 otherwise.",
+			ConstLabels: prometheus.Labels{"state": state.String()},
+		}, func() float64 {
+			if breaker.State() == state {
+				return 1
+			}
+			return 0
+		}))
+	}
+}
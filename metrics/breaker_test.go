@@ -0,0 +1,27 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"spacex-tracker/clients"
+)
+
+func TestRegisterBreaker_ReportsCurrentState(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	RegisterBreaker(registry, clients.NewCircuitBreaker(5, time.Minute))
+
+	expected := `
+# HELP spacex_tracker_upstream_circuit_state Circuit breaker state for the SpaceX API: 1 for the current state, 0 otherwise.
+# TYPE spacex_tracker_upstream_circuit_state gauge
+spacex_tracker_upstream_circuit_state{state="closed"} 1
+spacex_tracker_upstream_circuit_state{state="half-open"} 0
+spacex_tracker_upstream_circuit_state{state="open"} 0
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expected)); err != nil {
+		t.Fatal(err)
+	}
+}
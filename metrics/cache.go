@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"spacex-tracker/services"
+)
+
+type CacheMetrics struct {
+	lookups       *prometheus.CounterVec
+	errors        *prometheus.CounterVec
+	fetchDuration *prometheus.HistogramVec
+}
+
+func NewCacheMetrics(registry prometheus.Registerer) services.CacheMetrics {
+	m := &CacheMetrics{
+		lookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_lookups_total",
+			Help:      "Cache lookups by key and result (HIT, MISS, STALE or NEGATIVE).",
+		}, []string{"key", "result"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_errors_total",
+			Help:      "Cache failures by key and operation (get, decode, encode or set).",
+		}, []string{"key", "op"}),
+		fetchDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "cache_fetch_duration_seconds",
+			Help:      "Latency of upstream fetches made to fill the cache.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"key", "outcome"}),
+	}
+
+	registry.MustRegister(m.lookups, m.errors, m.fetchDuration)
+	return m
+}
+
+func (m *CacheMetrics) Lookup(key string, status services.CacheStatus) {
+	m.lookups.WithLabelValues(key, string(status)).Inc()
+}
+
+func (m *CacheMetrics) Error(key string, op string) {
+	m.errors.WithLabelValues(key, op).Inc()
+}
+
+func (m *CacheMetrics) Fetch(key string, duration time.Duration, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	m.fetchDuration.WithLabelValues(key, outcome).Observe(duration.Seconds())
+}
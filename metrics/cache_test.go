@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"spacex-tracker/models"
+	"spacex-tracker/services"
+	"spacex-tracker/services/cache"
+)
+
+type nextOnlyService struct {
+	services.LaunchService
+	err error
+}
+
+func (s *nextOnlyService) GetNext(ctx context.Context) (*models.Launch, error) {
+	return &models.Launch{Name: "Falcon 9"}, s.err
+}
+
+func TestCacheMetrics_RecordsLookupsAndFetches(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	m := NewCacheMetrics(registry).(*CacheMetrics)
+
+	svc := services.NewCachedLaunchService(&nextOnlyService{}, cache.NewMemoryCache(10), time.Minute,
+		services.WithMetrics(m),
+	)
+
+	for range 3 {
+		if _, err := svc.GetNext(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if got := testutil.ToFloat64(m.lookups.WithLabelValues("launch:next", "MISS")); got != 1 {
+		t.Fatalf("expected 1 miss, got %v", got)
+	}
+
+	if got := testutil.ToFloat64(m.lookups.WithLabelValues("launch:next", "HIT")); got != 2 {
+		t.Fatalf("expected 2 hits, got %v", got)
+	}
+
+	if got := testutil.CollectAndCount(m.fetchDuration); got != 1 {
+		t.Fatalf("expected 1 fetch histogram series, got %d", got)
+	}
+}
+
+func TestCacheMetrics_FetchErrorOutcome(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	m := NewCacheMetrics(registry)
+
+	m.Fetch("launch:next", time.Second, errors.New("upstream down"))
+	m.Error("launch:next", "set")
+
+	w := httptest.NewRecorder()
+	Handler(registry).ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	body := w.Body.String()
+	for _, want := range []string{
+		`spacex_tracker_cache_fetch_duration_seconds_count{key="launch:next",outcome="error"} 1`,
+		`spacex_tracker_cache_errors_total{key="launch:next",op="set"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("missing %q in:\n%s", want, body)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"spacex-tracker/clients"
+	"spacex-tracker/models"
+)
+
+type clientMetrics struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+type instrumentedClient struct {
+	inner   clients.SpaceXClient
+	metrics *clientMetrics
+}
+
+// NewInstrumentedClient wraps inner so that every call to the SpaceX API is
+// counted and timed per endpoint and status. Wrap the raw client, inside the
+// circuit breaker, so that only calls that actually reach upstream are seen.
+func NewInstrumentedClient(inner clients.SpaceXClient, registry prometheus.Registerer) clients.SpaceXClient {
+	m := &clientMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "upstream_requests_total",
+			Help:      "SpaceX API calls by endpoint and status (HTTP code, ok, timeout, malformed, canceled or error).",
+		}, []string{"endpoint", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "upstream_request_duration_seconds",
+			Help:      "Latency of SpaceX API calls, retries included.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"endpoint", "status"}),
+	}
+
+	registry.MustRegister(m.requests, m.duration)
+	return &instrumentedClient{inner: inner, metrics: m}
+}
+
+// upstreamStatus is the status label recorded for a call that returned err.
+func upstreamStatus(err error) string {
+	var statusErr *clients.UpstreamStatusError
+	switch {
+	case err == nil:
+		return "ok"
+	case errors.As(err, &statusErr):
+		return strconv.Itoa(statusErr.StatusCode)
+	case errors.Is(err, clients.ErrTimeout):
+		return "timeout"
+	case errors.Is(err, clients.ErrMalformedPayload):
+		return "malformed"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	default:
+		return "error"
+	}
+}
+
+func observe[T any](m *clientMetrics, endpoint string, call func() (T, error)) (T, error) {
+	start := time.Now()
+	result, err := call()
+
+	status := upstreamStatus(err)
+	m.requests.WithLabelValues(endpoint, status).Inc()
+	m.duration.WithLabelValues(endpoint, status).Observe(time.Since(start).Seconds())
+	return result, err
+}
+
+func (c *instrumentedClient) GetNext(ctx context.Context) (*models.Launch, error) {
+	return observe(c.metrics, "launches/next", func() (*models.Launch, error) { return c.inner.GetNext(ctx) })
+}
+
+func (c *instrumentedClient) GetLatest(ctx context.Context) (*models.Launch, error) {
+	return observe(c.metrics, "launches/latest", func() (*models.Launch, error) { return c.inner.GetLatest(ctx) })
+}
+
+func (c *instrumentedClient) GetUpcoming(ctx context.Context) ([]models.Launch, error) {
+	return observe(c.metrics, "launches/upcoming", func() ([]models.Launch, error) { return c.inner.GetUpcoming(ctx) })
+}
+
+func (c *instrumentedClient) GetPast(ctx context.Context) ([]models.Launch, error) {
+	return observe(c.metrics, "launches/past", func() ([]models.Launch, error) { return c.inner.GetPast(ctx) })
+}
+
+func (c *instrumentedClient) GetLaunch(ctx context.Context, id string) (*models.Launch, error) {
+	return observe(c.metrics, "launches/:id", func() (*models.Launch, error) { return c.inner.GetLaunch(ctx, id) })
+}
+
+func (c *instrumentedClient) QueryLaunches(ctx context.Context, query models.Query) (*models.Page[models.Launch], error) {
+	return observe(c.metrics, "launches/query", func() (*models.Page[models.Launch], error) { return c.inner.QueryLaunches(ctx, query) })
+}
+
+func (c *instrumentedClient) GetRocket(ctx context.Context, id string) (*models.Rocket, error) {
+	return observe(c.metrics, "rockets/:id", func() (*models.Rocket, error) { return c.inner.GetRocket(ctx, id) })
+}
+
+func (c *instrumentedClient) GetRockets(ctx context.Context) ([]models.Rocket, error) {
+	return observe(c.metrics, "rockets", func() ([]models.Rocket, error) { return c.inner.GetRockets(ctx) })
+}
+
+func (c *instrumentedClient) GetLaunchpad(ctx context.Context, id string) (*models.Launchpad, error) {
+	return observe(c.metrics, "launchpads/:id", func() (*models.Launchpad, error) { return c.inner.GetLaunchpad(ctx, id) })
+}
+
+func (c *instrumentedClient) GetLaunchpads(ctx context.Context) ([]models.Launchpad, error) {
+	return observe(c.metrics, "launchpads", func() ([]models.Launchpad, error) { return c.inner.GetLaunchpads(ctx) })
+}
+
+func (c *instrumentedClient) GetLandpad(ctx context.Context, id string) (*models.Landpad, error) {
+	return observe(c.metrics, "landpads/:id", func() (*models.Landpad, error) { return c.inner.GetLandpad(ctx, id) })
+}
+
+func (c *instrumentedClient) GetLandpads(ctx context.Context) ([]models.Landpad, error) {
+	return observe(c.metrics, "landpads", func() ([]models.Landpad, error) { return c.inner.GetLandpads(ctx) })
+}
+
+func (c *instrumentedClient) GetPayload(ctx context.Context, id string) (*models.Payload, error) {
+	return observe(c.metrics, "payloads/:id", func() (*models.Payload, error) { return c.inner.GetPayload(ctx, id) })
+}
+
+func (c *instrumentedClient) GetPayloads(ctx context.Context) ([]models.Payload, error) {
+	return observe(c.metrics, "payloads", func() ([]models.Payload, error) { return c.inner.GetPayloads(ctx) })
+}
+
+func (c *instrumentedClient) GetCore(ctx context.Context, id string) (*models.Core, error) {
+	return observe(c.metrics, "cores/:id", func() (*models.Core, error) { return c.inner.GetCore(ctx, id) })
+}
+
+func (c *instrumentedClient) GetCores(ctx context.Context) ([]models.Core, error) {
+	return observe(c.metrics, "cores", func() ([]models.Core, error) { return c.inner.GetCores(ctx) })
+}
+
+func (c *instrumentedClient) GetCapsule(ctx context.Context, id string) (*models.Capsule, error) {
+	return observe(c.metrics, "capsules/:id", func() (*models.Capsule, error) { return c.inner.GetCapsule(ctx, id) })
+}
+
+func (c *instrumentedClient) GetCapsules(ctx context.Context) ([]models.Capsule, error) {
+	return observe(c.metrics, "capsules", func() ([]models.Capsule, error) { return c.inner.GetCapsules(ctx) })
+}
+
+func (c *instrumentedClient) GetCrewMember(ctx context.Context, id string) (*models.CrewMember, error) {
+	return observe(c.metrics, "crew/:id", func() (*models.CrewMember, error) { return c.inner.GetCrewMember(ctx, id) })
+}
+
+func (c *instrumentedClient) GetCrew(ctx context.Context) ([]models.CrewMember, error) {
+	return observe(c.metrics, "crew", func() ([]models.CrewMember, error) { return c.inner.GetCrew(ctx) })
+}
+
+func (c *instrumentedClient) GetShip(ctx context.Context, id string) (*models.Ship, error) {
+	return observe(c.metrics, "ships/:id", func() (*models.Ship, error) { return c.inner.GetShip(ctx, id) })
+}
+
+func (c *instrumentedClient) GetShips(ctx context.Context) ([]models.Ship, error) {
+	return observe(c.metrics, "ships", func() ([]models.Ship, error) { return c.inner.GetShips(ctx) })
+}
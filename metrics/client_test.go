@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"spacex-tracker/clients"
+	"spacex-tracker/models"
+)
+
+type stubClient struct {
+	clients.SpaceXClient
+	err error
+}
+
+func (c *stubClient) GetNext(ctx context.Context) (*models.Launch, error) {
+	return &models.Launch{}, c.err
+}
+
+func (c *stubClient) GetRocket(ctx context.Context, id string) (*models.Rocket, error) {
+	return &models.Rocket{}, c.err
+}
+
+func TestInstrumentedClient_LabelsByEndpointAndStatus(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	stub := &stubClient{}
+	client := NewInstrumentedClient(stub, registry).(*instrumentedClient)
+
+	client.GetNext(context.Background())
+	client.GetRocket(context.Background(), "5e9d0d95eda69955f709d1eb")
+
+	stub.err = &clients.UpstreamStatusError{StatusCode: 503}
+	client.GetNext(context.Background())
+
+	stub.err = fmt.Errorf("%w: %w", clients.ErrTimeout, context.DeadlineExceeded)
+	client.GetNext(context.Background())
+
+	for _, tc := range []struct {
+		endpoint, status string
+	}{
+		{"launches/next", "ok"},
+		{"rockets/:id", "ok"},
+		{"launches/next", "503"},
+		{"launches/next", "timeout"},
+	} {
+		if got := testutil.ToFloat64(client.metrics.requests.WithLabelValues(tc.endpoint, tc.status)); got != 1 {
+			t.Errorf("%s %s: expected 1 request, got %v", tc.endpoint, tc.status, got)
+		}
+	}
+}
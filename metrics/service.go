@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"spacex-tracker/models"
+	"spacex-tracker/services"
+)
+
+type serviceMetrics struct {
+	calls    *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+type instrumentedService struct {
+	inner   services.LaunchService
+	metrics *serviceMetrics
+}
+
+// NewInstrumentedService wraps inner so that every LaunchService call is
+// counted and timed per method and outcome, cache hits included.
+func NewInstrumentedService(inner services.LaunchService, registry prometheus.Registerer) services.LaunchService {
+	m := &serviceMetrics{
+		calls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "service_calls_total",
+			Help:      "Launch service calls by method and outcome (success or error).",
+		}, []string{"method", "outcome"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "service_call_duration_seconds",
+			Help:      "Latency of launch service calls.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "outcome"}),
+	}
+
+	registry.MustRegister(m.calls, m.duration)
+	return &instrumentedService{inner: inner, metrics: m}
+}
+
+func record[T any](m *serviceMetrics, method string, call func() (T, error)) (T, error) {
+	start := time.Now()
+	result, err := call()
+
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	m.calls.WithLabelValues(method, outcome).Inc()
+	m.duration.WithLabelValues(method, outcome).Observe(time.Since(start).Seconds())
+	return result, err
+}
+
+func (s *instrumentedService) GetNext(ctx context.Context) (*models.Launch, error) {
+	return record(s.metrics, "GetNext", func() (*models.Launch, error) { return s.inner.GetNext(ctx) })
+}
+
+func (s *instrumentedService) GetLatest(ctx context.Context) (*models.Launch, error) {
+	return record(s.metrics, "GetLatest", func() (*models.Launch, error) { return s.inner.GetLatest(ctx) })
+}
+
+func (s *instrumentedService) GetUpcoming(ctx context.Context, filter services.LaunchFilter) ([]models.Launch, error) {
+	return record(s.metrics, "GetUpcoming", func() ([]models.Launch, error) { return s.inner.GetUpcoming(ctx, filter) })
+}
+
+func (s *instrumentedService) GetPast(ctx context.Context, sortOrder string, filter services.LaunchFilter) ([]models.Launch, error) {
+	return record(s.metrics, "GetPast", func() ([]models.Launch, error) { return s.inner.GetPast(ctx, sortOrder, filter) })
+}
+
+func (s *instrumentedService) GetByID(ctx context.Context, id string) (*models.Launch, error) {
+	return record(s.metrics, "GetByID", func() (*models.Launch, error) { return s.inner.GetByID(ctx, id) })
+}
+
+func (s *instrumentedService) GetByFlightNumber(ctx context.Context, flightNumber int) (*models.Launch, error) {
+	return record(s.metrics, "GetByFlightNumber", func() (*models.Launch, error) { return s.inner.GetByFlightNumber(ctx, flightNumber) })
+}
@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestInstrumentedService_RecordsOutcomes(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	inner := &nextOnlyService{}
+	svc := NewInstrumentedService(inner, registry).(*instrumentedService)
+
+	svc.GetNext(context.Background())
+	inner.err = errors.New("upstream down")
+	svc.GetNext(context.Background())
+
+	for _, outcome := range []string{"success", "error"} {
+		if got := testutil.ToFloat64(svc.metrics.calls.WithLabelValues("GetNext", outcome)); got != 1 {
+			t.Errorf("%s: expected 1 call, got %v", outcome, got)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package models
+
+type Capsule struct {
+	Id            string   `json:"id"`
+	Serial        string   `json:"serial"`
+	Status        string   `json:"status"`
+	Type          string   `json:"type"`
+	ReuseCount    int      `json:"reuse_count"`
+	WaterLandings int      `json:"water_landings"`
+	LandLandings  int      `json:"land_landings"`
+	LastUpdate    *string  `json:"last_update"`
+	Launches      []string `json:"launches,omitempty"`
+}
@@ -0,0 +1,15 @@
+package models
+
+type Core struct {
+	Id           string   `json:"id"`
+	Serial       string   `json:"serial"`
+	Block        *int     `json:"block"` // nullable
+	Status       string   `json:"status"`
+	ReuseCount   int      `json:"reuse_count"`
+	RTLSAttempts int      `json:"rtls_attempts"`
+	RTLSLandings int      `json:"rtls_landings"`
+	ASDSAttempts int      `json:"asds_attempts"`
+	ASDSLandings int      `json:"asds_landings"`
+	LastUpdate   *string  `json:"last_update"`
+	Launches     []string `json:"launches,omitempty"`
+}
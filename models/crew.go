@@ -0,0 +1,11 @@
+package models
+
+type CrewMember struct {
+	Id        string   `json:"id"`
+	Name      string   `json:"name"`
+	Agency    string   `json:"agency"`
+	Status    string   `json:"status"`
+	Image     string   `json:"image,omitempty"`
+	Wikipedia string   `json:"wikipedia,omitempty"`
+	Launches  []string `json:"launches,omitempty"`
+}
@@ -0,0 +1,19 @@
+package models
+
+type Landpad struct {
+	Id               string   `json:"id"`
+	Name             string   `json:"name"`
+	FullName         string   `json:"full_name"`
+	Status           string   `json:"status"`
+	Type             string   `json:"type"` // RTLS or ASDS
+	Locality         string   `json:"locality"`
+	Region           string   `json:"region"`
+	Latitude         float64  `json:"latitude"`
+	Longitude        float64  `json:"longitude"`
+	LandingAttempts  int      `json:"landing_attempts"`
+	LandingSuccesses int      `json:"landing_successes"`
+	Wikipedia        string   `json:"wikipedia,omitempty"`
+	Details          string   `json:"details,omitempty"`
+	Launches         []string `json:"launches,omitempty"`
+	Images           Images   `json:"images"`
+}
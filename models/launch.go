@@ -5,8 +5,64 @@ import "time"
 type Launch struct {
 	Id string `json:"id"`
 	Name string `json:"name"`
+	FlightNumber int `json:"flight_number"`
 	DateUTC time.Time `json:"date_utc"`
+	DateLocal time.Time `json:"date_local"` // launch site's time zone
+	DatePrecision string `json:"date_precision"` // half | quarter | year | month | day | hour
+	TBD bool `json:"tbd"` // date not yet decided
+	NET bool `json:"net"` // "no earlier than" date
+	Window *int `json:"window"` // seconds, nullable
+	StaticFireDateUTC *time.Time `json:"static_fire_date_utc"` // nullable
 	Success *bool `json:"success,omitempty"` // nullable
 	Upcoming bool `json:"upcoming"`
 	Details string `json:"details,omitempty"`
+	Rocket string `json:"rocket"` // rocket ID
+	Launchpad string `json:"launchpad"` // launchpad ID
+	Payloads []string `json:"payloads"` // payload IDs
+	Crew []string `json:"crew"` // crew member IDs
+	Capsules []string `json:"capsules"` // capsule IDs
+	Ships []string `json:"ships"` // ship IDs
+	Cores []LaunchCore `json:"cores"`
+	Failures []LaunchFailure `json:"failures"`
+	Links LaunchLinks `json:"links"`
+}
+
+// LaunchCore is one first-stage core flown on a launch and how it landed.
+// Fields are null until SpaceX assigns a core or plans the landing.
+type LaunchCore struct {
+	Core *string `json:"core"` // core ID
+	Flight *int `json:"flight"` // this core's nth flight
+	Gridfins *bool `json:"gridfins"`
+	Legs *bool `json:"legs"`
+	Reused *bool `json:"reused"`
+	LandingAttempt *bool `json:"landing_attempt"`
+	LandingSuccess *bool `json:"landing_success"`
+	LandingType *string `json:"landing_type"` // ASDS | RTLS | Ocean
+	Landpad *string `json:"landpad"` // landpad ID
+}
+
+type LaunchFailure struct {
+	Time int `json:"time"` // seconds after liftoff
+	Altitude *int `json:"altitude"` // km, nullable
+	Reason string `json:"reason"`
+}
+
+type LaunchLinks struct {
+	Patch LaunchPatch `json:"patch"`
+	Reddit LaunchReddit `json:"reddit"`
+	Presskit *string `json:"presskit"`
+	Webcast *string `json:"webcast"`
+	Wikipedia *string `json:"wikipedia"`
+}
+
+type LaunchPatch struct {
+	Small *string `json:"small"`
+	Large *string `json:"large"`
+}
+
+type LaunchReddit struct {
+	Campaign *string `json:"campaign"`
+	Launch *string `json:"launch"`
+	Media *string `json:"media"`
+	Recovery *string `json:"recovery"`
 }
\ No newline at end of file
@@ -0,0 +1,165 @@
+package models
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Fixtures are launch documents as served by the SpaceX v4 API.
+var launchFixtures = []string{"launch_falconsat.json", "launch_crew1.json", "launch_upcoming.json"}
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func decodeLaunch(t *testing.T, data []byte) Launch {
+	t.Helper()
+
+	var launch Launch
+	if err := json.Unmarshal(data, &launch); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	return launch
+}
+
+func TestLaunch_DecodesFailedLaunch(t *testing.T) {
+	launch := decodeLaunch(t, readFixture(t, "launch_falconsat.json"))
+
+	if launch.FlightNumber != 1 || launch.DatePrecision != "hour" || launch.TBD || launch.NET {
+		t.Fatalf("unexpected scheduling fields %+v", launch)
+	}
+	if _, offset := launch.DateLocal.Zone(); offset != 12*60*60 || !launch.DateLocal.Equal(launch.DateUTC) {
+		t.Fatalf("expected date_local in the launch site's zone, got %s", launch.DateLocal)
+	}
+	if launch.Window == nil || *launch.Window != 0 {
+		t.Fatalf("expected a zero window, got %v", launch.Window)
+	}
+	if launch.StaticFireDateUTC == nil || !launch.StaticFireDateUTC.Equal(time.Date(2006, 3, 17, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected static fire date %v", launch.StaticFireDateUTC)
+	}
+
+	if len(launch.Failures) != 1 || launch.Failures[0].Time != 33 || launch.Failures[0].Altitude != nil || launch.Failures[0].Reason != "merlin engine failure" {
+		t.Fatalf("unexpected failures %+v", launch.Failures)
+	}
+
+	if len(launch.Cores) != 1 || *launch.Cores[0].LandingAttempt || launch.Cores[0].LandingSuccess != nil || launch.Cores[0].LandingType != nil {
+		t.Fatalf("unexpected cores %+v", launch.Cores)
+	}
+
+	if launch.Links.Patch.Small == nil || launch.Links.Presskit != nil || launch.Links.Reddit.Campaign != nil ||
+		*launch.Links.Wikipedia != "https://en.wikipedia.org/wiki/DemoSat" {
+		t.Fatalf("unexpected links %+v", launch.Links)
+	}
+}
+
+func TestLaunch_DecodesCrewedLaunch(t *testing.T) {
+	launch := decodeLaunch(t, readFixture(t, "launch_crew1.json"))
+
+	if len(launch.Crew) != 4 || len(launch.Capsules) != 1 || len(launch.Ships) != 2 || len(launch.Payloads) != 1 {
+		t.Fatalf("unexpected related IDs %+v", launch)
+	}
+	if launch.StaticFireDateUTC != nil || len(launch.Failures) != 0 || launch.Success == nil || !*launch.Success {
+		t.Fatalf("unexpected outcome %+v", launch)
+	}
+
+	core := launch.Cores[0]
+	if *core.Core != "5ef670f10059c33cee4a826c" || *core.Flight != 1 || *core.Reused ||
+		!*core.LandingSuccess || *core.LandingType != "ASDS" || *core.Landpad != "5e9e3032383ecb6bb234e7ca" {
+		t.Fatalf("unexpected core %+v", core)
+	}
+
+	if *launch.Links.Webcast != "https://youtu.be/bnChQbxLkkI" || launch.Links.Reddit.Launch == nil || launch.Links.Reddit.Media != nil {
+		t.Fatalf("unexpected links %+v", launch.Links)
+	}
+}
+
+func TestLaunch_DecodesUndecidedLaunch(t *testing.T) {
+	launch := decodeLaunch(t, readFixture(t, "launch_upcoming.json"))
+
+	if !launch.TBD || !launch.NET || launch.DatePrecision != "month" || launch.Window != nil || launch.Success != nil {
+		t.Fatalf("unexpected scheduling fields %+v", launch)
+	}
+	if core := launch.Cores[0]; core.Core != nil || core.Flight != nil || core.Reused != nil || !*core.Gridfins {
+		t.Fatalf("unexpected core %+v", core)
+	}
+	if launch.Links.Patch.Small != nil || launch.Links.Webcast != nil {
+		t.Fatalf("unexpected links %+v", launch.Links)
+	}
+}
+
+func TestLaunch_RoundTrip(t *testing.T) {
+	for _, name := range launchFixtures {
+		t.Run(name, func(t *testing.T) {
+			fixture := readFixture(t, name)
+			launch := decodeLaunch(t, fixture)
+
+			encoded, err := json.Marshal(launch)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if again := decodeLaunch(t, encoded); !reflect.DeepEqual(again, launch) {
+				t.Fatalf("launch changed across a round trip:\n%+v\n%+v", launch, again)
+			}
+
+			// Every field we emit must carry the upstream name and value.
+			var want, got any
+			json.Unmarshal(fixture, &want)
+			json.Unmarshal(encoded, &got)
+			assertSubset(t, "launch", got, want)
+		})
+	}
+}
+
+// assertSubset fails unless every value in got is present and equal in want.
+// Timestamps are compared as instants, since upstream adds milliseconds.
+func assertSubset(t *testing.T, path string, got, want any) {
+	t.Helper()
+
+	switch got := got.(type) {
+	case map[string]any:
+		wantMap, ok := want.(map[string]any)
+		if !ok {
+			t.Fatalf("%s: expected %v, got an object", path, want)
+		}
+		for key, value := range got {
+			wantValue, ok := wantMap[key]
+			if !ok {
+				t.Fatalf("%s.%s is not in the upstream payload", path, key)
+			}
+			assertSubset(t, path+"."+key, value, wantValue)
+		}
+	case []any:
+		wantSlice, ok := want.([]any)
+		if !ok || len(wantSlice) != len(got) {
+			t.Fatalf("%s: expected %v, got %v", path, want, got)
+		}
+		for i := range got {
+			assertSubset(t, path, got[i], wantSlice[i])
+		}
+	case string:
+		wantString, _ := want.(string)
+		if got == wantString {
+			return
+		}
+		gotTime, err1 := time.Parse(time.RFC3339, got)
+		wantTime, err2 := time.Parse(time.RFC3339, wantString)
+		if err1 != nil || err2 != nil || !gotTime.Equal(wantTime) {
+			t.Fatalf("%s: expected %q, got %q", path, wantString, got)
+		}
+	default:
+		if got != want {
+			t.Fatalf("%s: expected %v, got %v", path, want, got)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package models
+
+type Images struct {
+	Large []string `json:"large,omitempty"`
+}
+
+type Launchpad struct {
+	Id              string   `json:"id"`
+	Name            string   `json:"name"`
+	FullName        string   `json:"full_name"`
+	Status          string   `json:"status"`
+	Locality        string   `json:"locality"`
+	Region          string   `json:"region"`
+	Timezone        string   `json:"timezone"`
+	Latitude        float64  `json:"latitude"`
+	Longitude       float64  `json:"longitude"`
+	LaunchAttempts  int      `json:"launch_attempts"`
+	LaunchSuccesses int      `json:"launch_successes"`
+	Rockets         []string `json:"rockets,omitempty"`
+	Launches        []string `json:"launches,omitempty"`
+	Details         string   `json:"details,omitempty"`
+	Images          Images   `json:"images"`
+}
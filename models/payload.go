@@ -0,0 +1,29 @@
+package models
+
+type PayloadDragon struct {
+	Capsule         *string  `json:"capsule"` // nullable
+	MassReturnedKg  *float64 `json:"mass_returned_kg"`
+	MassReturnedLbs *float64 `json:"mass_returned_lbs"`
+	FlightTimeSec   *int64   `json:"flight_time_sec"`
+	Manifest        *string  `json:"manifest"`
+	WaterLanding    *bool    `json:"water_landing"`
+	LandLanding     *bool    `json:"land_landing"`
+}
+
+type Payload struct {
+	Id              string        `json:"id"`
+	Name            string        `json:"name"`
+	Type            string        `json:"type"`
+	Reused          bool          `json:"reused"`
+	Launch          *string       `json:"launch"` // nullable
+	Customers       []string      `json:"customers,omitempty"`
+	NoradIds        []int         `json:"norad_ids,omitempty"`
+	Nationalities   []string      `json:"nationalities,omitempty"`
+	Manufacturers   []string      `json:"manufacturers,omitempty"`
+	MassKg          *float64      `json:"mass_kg"`
+	MassLbs         *float64      `json:"mass_lbs"`
+	Orbit           *string       `json:"orbit"`
+	ReferenceSystem *string       `json:"reference_system"`
+	Regime          *string       `json:"regime"`
+	Dragon          PayloadDragon `json:"dragon"`
+}
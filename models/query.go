@@ -0,0 +1,32 @@
+package models
+
+// Query is the request body accepted by the SpaceX `POST /<resource>/query` endpoints.
+// Query holds a MongoDB-style filter, e.g. {"upcoming": false, "success": true}.
+type Query struct {
+	Query   map[string]any `json:"query"`
+	Options QueryOptions   `json:"options"`
+}
+
+type QueryOptions struct {
+	Sort       map[string]string `json:"sort,omitempty"` // field -> "asc" | "desc"
+	Limit      int               `json:"limit,omitempty"`
+	Page       int               `json:"page,omitempty"`
+	Select     []string          `json:"select,omitempty"`
+	Populate   []string          `json:"populate,omitempty"`
+	Pagination *bool             `json:"pagination,omitempty"`
+}
+
+// Page is the paginated response envelope returned by the query endpoints.
+type Page[T any] struct {
+	Docs          []T  `json:"docs"`
+	TotalDocs     int  `json:"totalDocs"`
+	Offset        int  `json:"offset"`
+	Limit         int  `json:"limit"`
+	TotalPages    int  `json:"totalPages"`
+	Page          int  `json:"page"`
+	PagingCounter int  `json:"pagingCounter"`
+	HasPrevPage   bool `json:"hasPrevPage"`
+	HasNextPage   bool `json:"hasNextPage"`
+	PrevPage      *int `json:"prevPage"` // nullable
+	NextPage      *int `json:"nextPage"` // nullable
+}
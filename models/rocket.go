@@ -0,0 +1,31 @@
+package models
+
+type Dimension struct {
+	Meters *float64 `json:"meters,omitempty"`
+	Feet   *float64 `json:"feet,omitempty"`
+}
+
+type Mass struct {
+	Kg *float64 `json:"kg,omitempty"`
+	Lb *float64 `json:"lb,omitempty"`
+}
+
+type Rocket struct {
+	Id             string    `json:"id"`
+	Name           string    `json:"name"`
+	Type           string    `json:"type"`
+	Active         bool      `json:"active"`
+	Stages         int       `json:"stages"`
+	Boosters       int       `json:"boosters"`
+	CostPerLaunch  int64     `json:"cost_per_launch"`
+	SuccessRatePct int       `json:"success_rate_pct"`
+	FirstFlight    string    `json:"first_flight"` // YYYY-MM-DD
+	Country        string    `json:"country"`
+	Company        string    `json:"company"`
+	Height         Dimension `json:"height"`
+	Diameter       Dimension `json:"diameter"`
+	Mass           Mass      `json:"mass"`
+	Description    string    `json:"description,omitempty"`
+	Wikipedia      string    `json:"wikipedia,omitempty"`
+	FlickrImages   []string  `json:"flickr_images,omitempty"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+type Ship struct {
+	Id            string     `json:"id"`
+	Name          string     `json:"name"`
+	LegacyId      *string    `json:"legacy_id"`
+	Model         *string    `json:"model"`
+	Type          string     `json:"type"`
+	Roles         []string   `json:"roles,omitempty"`
+	Active        bool       `json:"active"`
+	IMO           *int       `json:"imo"`
+	MMSI          *int       `json:"mmsi"`
+	ABS           *int       `json:"abs"`
+	Class         *int       `json:"class"`
+	MassKg        *float64   `json:"mass_kg"`
+	MassLbs       *float64   `json:"mass_lbs"`
+	YearBuilt     *int       `json:"year_built"`
+	HomePort      string     `json:"home_port"`
+	Status        string     `json:"status"`
+	SpeedKn       *float64   `json:"speed_kn"`
+	CourseDeg     *float64   `json:"course_deg"`
+	Latitude      *float64   `json:"latitude"`
+	Longitude     *float64   `json:"longitude"`
+	LastAISUpdate *time.Time `json:"last_ais_update"`
+	Link          string     `json:"link,omitempty"`
+	Image         string     `json:"image,omitempty"`
+	Launches      []string   `json:"launches,omitempty"`
+}
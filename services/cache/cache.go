@@ -2,18 +2,47 @@ package cache
 
 import (
 	"context"
+	"errors"
+	"sync/atomic"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
+// ErrMiss is returned by Get when the key is absent or expired.
+var ErrMiss = errors.New("cache miss")
+
 type Cache interface {
 	Get(ctx context.Context, key string) ([]byte, error)
 	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+
+	// DeleteMatching removes every key matching a Redis-style glob pattern,
+	// e.g. "launch:*", and returns how many were removed.
+	DeleteMatching(ctx context.Context, pattern string) (int, error)
+	// Keys lists the keys matching pattern with their remaining TTL.
+	Keys(ctx context.Context, pattern string) ([]KeyInfo, error)
+	Stats(ctx context.Context) (Stats, error)
+}
+
+type KeyInfo struct {
+	Key string
+	TTL time.Duration // negative when the key never expires
+}
+
+type Stats struct {
+	Name   string  `json:"name"`
+	Hits   uint64  `json:"hits"`
+	Misses uint64  `json:"misses"`
+	Keys   int     `json:"keys"`
+	Tiers  []Stats `json:"tiers,omitempty"` // per-tier breakdown of a layered cache
 }
 
 type RedisCache struct {
 	client *redis.Client
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
 }
 
 func NewRedisCache(client *redis.Client) Cache {
@@ -23,10 +52,88 @@ func NewRedisCache(client *redis.Client) Cache {
 }
 
 func (r *RedisCache) Get(ctx context.Context, key string) ([]byte, error) {
-	return r.client.Get(ctx, key).Bytes()
+	data, err := r.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		r.misses.Add(1)
+		return nil, ErrMiss
+	}
+	if err == nil {
+		r.hits.Add(1)
+	}
+	return data, err
 }
 
 
 func (r *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
 	return r.client.Set(ctx, key, value, ttl).Err()
-}
\ No newline at end of file
+}
+
+func (r *RedisCache) Delete(ctx context.Context, key string) error {
+	return r.client.Del(ctx, key).Err()
+}
+
+func (r *RedisCache) DeleteMatching(ctx context.Context, pattern string) (int, error) {
+	keys, err := r.scan(ctx, pattern)
+	if err != nil || len(keys) == 0 {
+		return 0, err
+	}
+
+	deleted, err := r.client.Del(ctx, keys...).Result()
+	return int(deleted), err
+}
+
+func (r *RedisCache) Keys(ctx context.Context, pattern string) ([]KeyInfo, error) {
+	keys, err := r.scan(ctx, pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := r.client.Pipeline()
+	ttls := make([]*redis.DurationCmd, len(keys))
+	for i, key := range keys {
+		ttls[i] = pipe.PTTL(ctx, key)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	infos := make([]KeyInfo, 0, len(keys))
+	for i, key := range keys {
+		ttl := ttls[i].Val()
+		if ttl == -2 { // expired between SCAN and PTTL
+			continue
+		}
+		if ttl < 0 {
+			ttl = -1
+		}
+		infos = append(infos, KeyInfo{Key: key, TTL: ttl})
+	}
+
+	return infos, nil
+}
+
+func (r *RedisCache) Stats(ctx context.Context) (Stats, error) {
+	size, err := r.client.DBSize(ctx).Result()
+	if err != nil {
+		return Stats{}, err
+	}
+
+	return Stats{
+		Name:   "redis",
+		Hits:   r.hits.Load(),
+		Misses: r.misses.Load(),
+		Keys:   int(size),
+	}, nil
+}
+
+// scan collects the keys matching pattern without blocking Redis like KEYS would.
+func (r *RedisCache) scan(ctx context.Context, pattern string) ([]string, error) {
+	var keys []string
+
+	iter := r.client.Scan(ctx, 0, pattern, 100).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+
+	return keys, iter.Err()
+}
@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestRedisCache(t *testing.T) (Cache, *miniredis.Miniredis) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	return NewRedisCache(client), server
+}
+
+func TestRedisCache_MissIsErrMiss(t *testing.T) {
+	c, _ := newTestRedisCache(t)
+
+	if _, err := c.Get(context.Background(), "launch:next"); !errors.Is(err, ErrMiss) {
+		t.Fatalf("expected ErrMiss, got %v", err)
+	}
+}
+
+func TestRedisCache_KeysAndDeleteMatching(t *testing.T) {
+	c, server := newTestRedisCache(t)
+	ctx := context.Background()
+
+	c.Set(ctx, "launch:next", []byte("next"), time.Minute)
+	c.Set(ctx, "launch:past:asc", []byte("past"), 0)
+	c.Set(ctx, "other", []byte("x"), 0)
+
+	keys, err := c.Keys(ctx, "launch:*")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	slices.SortFunc(keys, func(a, b KeyInfo) int { return strings.Compare(a.Key, b.Key) })
+	if len(keys) != 2 || keys[0].Key != "launch:next" || keys[0].TTL != time.Minute || keys[1].TTL != -1 {
+		t.Fatalf("unexpected keys: %+v", keys)
+	}
+
+	deleted, err := c.DeleteMatching(ctx, "launch:*")
+	if err != nil || deleted != 2 {
+		t.Fatalf("expected 2 deleted, got %d, %v", deleted, err)
+	}
+
+	if !server.Exists("other") {
+		t.Fatal("unmatched key should be kept")
+	}
+}
+
+func TestRedisCache_DeleteAndStats(t *testing.T) {
+	c, _ := newTestRedisCache(t)
+	ctx := context.Background()
+
+	c.Set(ctx, "launch:next", []byte("next"), 0)
+	c.Get(ctx, "launch:next")
+	c.Delete(ctx, "launch:next")
+	c.Get(ctx, "launch:next")
+
+	stats, err := c.Stats(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if stats.Hits != 1 || stats.Misses != 1 || stats.Keys != 0 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
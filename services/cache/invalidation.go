@@ -0,0 +1,163 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"spacex-tracker/logging"
+)
+
+const InvalidationChannel = "spacex-tracker:cache:invalidate"
+
+// invalidation is the message published when a replica purges cache keys.
+// Exactly one of Key and Pattern is set.
+type invalidation struct {
+	Origin  string `json:"origin"`
+	Key     string `json:"key,omitempty"`
+	Pattern string `json:"pattern,omitempty"`
+}
+
+// Invalidator broadcasts purges to the other replicas over Redis pub/sub and
+// evicts the keys they purge from this replica's local tier.
+type Invalidator struct {
+	client *redis.Client
+	local  Cache
+	origin string
+}
+
+func NewInvalidator(client *redis.Client, local Cache) *Invalidator {
+	return &Invalidator{
+		client: client,
+		local:  local,
+		origin: replicaID(),
+	}
+}
+
+// replicaID identifies this process so it can ignore its own events.
+func replicaID() string {
+	suffix := make([]byte, 4)
+	rand.Read(suffix)
+
+	host, _ := os.Hostname()
+	return host + "-" + hex.EncodeToString(suffix)
+}
+
+func (i *Invalidator) publish(ctx context.Context, event invalidation) error {
+	event.Origin = i.origin
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return i.client.Publish(ctx, InvalidationChannel, payload).Err()
+}
+
+// Run applies invalidations from other replicas until ctx is done,
+// resubscribing with backoff whenever the connection drops.
+func (i *Invalidator) Run(ctx context.Context) {
+	const maxBackoff = 30 * time.Second
+	backoff := time.Second
+	reconnecting := false
+
+	for ctx.Err() == nil {
+		subscribed, err := i.subscribe(ctx, reconnecting)
+		if ctx.Err() != nil {
+			return
+		}
+		if subscribed {
+			backoff = time.Second
+		}
+
+		logging.FromContext(ctx).Warn("cache invalidation subscription lost", "error", err, "retry_in", backoff.String())
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*2, maxBackoff)
+		reconnecting = true
+	}
+}
+
+// subscribe listens for invalidations until the connection fails, reporting
+// whether the subscription was established at all.
+func (i *Invalidator) subscribe(ctx context.Context, reconnecting bool) (bool, error) {
+	pubsub := i.client.Subscribe(ctx, InvalidationChannel)
+	defer pubsub.Close()
+
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return false, err
+	}
+
+	if reconnecting {
+		// Events published while we were away are lost; drop the whole local
+		// tier rather than serve keys another replica may have purged.
+		if _, err := i.local.DeleteMatching(ctx, "*"); err != nil {
+			return true, err
+		}
+		logging.FromContext(ctx).Info("cache invalidation subscription restored")
+	}
+
+	for {
+		message, err := pubsub.ReceiveMessage(ctx)
+		if err != nil {
+			return true, err
+		}
+		i.apply(ctx, []byte(message.Payload))
+	}
+}
+
+func (i *Invalidator) apply(ctx context.Context, payload []byte) {
+	var event invalidation
+	if err := json.Unmarshal(payload, &event); err != nil {
+		logging.FromContext(ctx).Warn("ignoring malformed cache invalidation", "error", err)
+		return
+	}
+
+	if event.Origin == i.origin {
+		return
+	}
+
+	var err error
+	switch {
+	case event.Key != "":
+		err = i.local.Delete(ctx, event.Key)
+	case event.Pattern != "":
+		_, err = i.local.DeleteMatching(ctx, event.Pattern)
+	}
+	if err != nil {
+		logging.FromContext(ctx).Error("failed to apply cache invalidation", "key", event.Key, "pattern", event.Pattern, "error", err)
+	}
+}
+
+type broadcastingCache struct {
+	Cache
+	invalidator *Invalidator
+}
+
+// NewBroadcastingCache wraps c so that every Delete and DeleteMatching is also
+// published to the other replicas through invalidator.
+func NewBroadcastingCache(c Cache, invalidator *Invalidator) Cache {
+	return &broadcastingCache{
+		Cache:       c,
+		invalidator: invalidator,
+	}
+}
+
+func (b *broadcastingCache) Delete(ctx context.Context, key string) error {
+	err := b.Cache.Delete(ctx, key)
+	return errors.Join(err, b.invalidator.publish(ctx, invalidation{Key: key}))
+}
+
+func (b *broadcastingCache) DeleteMatching(ctx context.Context, pattern string) (int, error) {
+	deleted, err := b.Cache.DeleteMatching(ctx, pattern)
+	return deleted, errors.Join(err, b.invalidator.publish(ctx, invalidation{Pattern: pattern}))
+}
@@ -0,0 +1,87 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+type replica struct {
+	local Cache
+	store Cache
+}
+
+func newReplica(t *testing.T, server *miniredis.Miniredis) (*replica, *Invalidator) {
+	t.Helper()
+
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	local := NewMemoryCache(10)
+	invalidator := NewInvalidator(client, local)
+	store := NewBroadcastingCache(NewTieredCache(local, NewRedisCache(client), time.Minute), invalidator)
+
+	return &replica{local: local, store: store}, invalidator
+}
+
+func waitForMiss(t *testing.T, c Cache, key string) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if _, err := c.Get(context.Background(), key); errors.Is(err, ErrMiss) {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("expected %s to be evicted", key)
+}
+
+func TestInvalidator_PurgeReachesOtherReplicas(t *testing.T) {
+	server := miniredis.RunT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	a, _ := newReplica(t, server)
+	b, invalidatorB := newReplica(t, server)
+	go invalidatorB.Run(ctx)
+
+	for server.PubSubNumSub(InvalidationChannel)[InvalidationChannel] == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	b.local.Set(ctx, "launch:next", []byte("next"), 0)
+	b.local.Set(ctx, "launch:past:asc", []byte("asc"), 0)
+
+	if err := a.store.Delete(ctx, "launch:next"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	waitForMiss(t, b.local, "launch:next")
+
+	if _, err := a.store.DeleteMatching(ctx, "launch:past:*"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	waitForMiss(t, b.local, "launch:past:asc")
+}
+
+func TestInvalidator_IgnoresOwnEvents(t *testing.T) {
+	server := miniredis.RunT(t)
+	a, invalidatorA := newReplica(t, server)
+	ctx := context.Background()
+
+	a.local.Set(ctx, "launch:next", []byte("next"), 0)
+
+	invalidatorA.apply(ctx, []byte(`{"origin":"`+invalidatorA.origin+`","key":"launch:next"}`))
+	if _, err := a.local.Get(ctx, "launch:next"); err != nil {
+		t.Fatal("own event should be ignored")
+	}
+
+	invalidatorA.apply(ctx, []byte(`{"origin":"someone-else","key":"launch:next"}`))
+	if _, err := a.local.Get(ctx, "launch:next"); !errors.Is(err, ErrMiss) {
+		t.Fatal("event from another replica should evict the key")
+	}
+}
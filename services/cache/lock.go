@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrLocked is returned by Lock when another owner holds the lock.
+var ErrLocked = errors.New("lock held by another owner")
+
+// Locker provides a lock shared by every replica. The lock expires after ttl
+// even if it is never released, so a crashed owner can't hold it forever.
+type Locker interface {
+	Lock(ctx context.Context, key string, ttl time.Duration) (unlock func(context.Context) error, err error)
+}
+
+type RedisLocker struct {
+	client *redis.Client
+}
+
+func NewRedisLocker(client *redis.Client) Locker {
+	return &RedisLocker{
+		client: client,
+	}
+}
+
+// releaseScript deletes the lock only if it still holds our token, so we never
+// release a lock that expired and was taken by someone else.
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+func (r *RedisLocker) Lock(ctx context.Context, key string, ttl time.Duration) (func(context.Context) error, error) {
+	raw := make([]byte, 16)
+	rand.Read(raw)
+	token := hex.EncodeToString(raw)
+
+	acquired, err := r.client.SetNX(ctx, key, token, ttl).Result()
+	if err != nil {
+		return nil, err
+	}
+	if !acquired {
+		return nil, ErrLocked
+	}
+
+	return func(ctx context.Context) error {
+		return releaseScript.Run(ctx, r.client, []string{key}, token).Err()
+	}, nil
+}
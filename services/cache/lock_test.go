@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestRedisLocker(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	first, second := NewRedisLocker(client), NewRedisLocker(client)
+	ctx := context.Background()
+
+	unlock, err := first.Lock(ctx, "lock:warmer", time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := second.Lock(ctx, "lock:warmer", time.Minute); !errors.Is(err, ErrLocked) {
+		t.Fatalf("expected ErrLocked, got %v", err)
+	}
+
+	if err := unlock(ctx); err != nil {
+		t.Fatalf("unexpected unlock error: %v", err)
+	}
+
+	if _, err := second.Lock(ctx, "lock:warmer", time.Minute); err != nil {
+		t.Fatalf("expected lock to be free after unlock, got %v", err)
+	}
+}
+
+func TestRedisLocker_StaleUnlockKeepsNewOwner(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	locker := NewRedisLocker(client)
+	ctx := context.Background()
+
+	staleUnlock, _ := locker.Lock(ctx, "lock:warmer", time.Second)
+	server.FastForward(2 * time.Second)
+
+	if _, err := locker.Lock(ctx, "lock:warmer", time.Minute); err != nil {
+		t.Fatalf("expected expired lock to be re-acquired, got %v", err)
+	}
+
+	staleUnlock(ctx)
+
+	if !server.Exists("lock:warmer") {
+		t.Fatal("stale owner must not release the new owner's lock")
+	}
+}
@@ -0,0 +1,160 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"path"
+	"sync"
+	"time"
+)
+
+// MemoryCache is an in-process LRU cache bounded to maxEntries keys.
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	items      map[string]*list.Element
+	order      *list.List // front is most recently used
+	now        func() time.Time
+
+	hits   uint64
+	misses uint64
+}
+
+type memoryItem struct {
+	key       string
+	value     []byte
+	expiresAt time.Time // zero means no expiry
+}
+
+func NewMemoryCache(maxEntries int) Cache {
+	return &MemoryCache{
+		maxEntries: max(maxEntries, 1),
+		items:      make(map[string]*list.Element),
+		order:      list.New(),
+		now:        time.Now,
+	}
+}
+
+func (m *MemoryCache) Get(ctx context.Context, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	element, ok := m.items[key]
+	if !ok {
+		m.misses++
+		return nil, ErrMiss
+	}
+
+	item := element.Value.(*memoryItem)
+	if m.expired(item) {
+		m.remove(element)
+		m.misses++
+		return nil, ErrMiss
+	}
+
+	m.order.MoveToFront(element)
+	m.hits++
+	return item.value, nil
+}
+
+// Set stores value under key. A non-positive ttl never expires, like Redis.
+func (m *MemoryCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	item := &memoryItem{
+		key:   key,
+		value: append([]byte(nil), value...),
+	}
+	if ttl > 0 {
+		item.expiresAt = m.now().Add(ttl)
+	}
+
+	if element, ok := m.items[key]; ok {
+		element.Value = item
+		m.order.MoveToFront(element)
+		return nil
+	}
+
+	m.items[key] = m.order.PushFront(item)
+	for m.order.Len() > m.maxEntries {
+		m.remove(m.order.Back())
+	}
+
+	return nil
+}
+
+func (m *MemoryCache) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if element, ok := m.items[key]; ok {
+		m.remove(element)
+	}
+	return nil
+}
+
+func (m *MemoryCache) DeleteMatching(ctx context.Context, pattern string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	deleted := 0
+	for key, element := range m.items {
+		if matched, err := path.Match(pattern, key); err != nil {
+			return deleted, err
+		} else if matched {
+			m.remove(element)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (m *MemoryCache) Keys(ctx context.Context, pattern string) ([]KeyInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var infos []KeyInfo
+	for key, element := range m.items {
+		item := element.Value.(*memoryItem)
+		if m.expired(item) {
+			continue
+		}
+
+		matched, err := path.Match(pattern, key)
+		if err != nil {
+			return nil, err
+		}
+		if !matched {
+			continue
+		}
+
+		ttl := time.Duration(-1)
+		if !item.expiresAt.IsZero() {
+			ttl = item.expiresAt.Sub(m.now())
+		}
+		infos = append(infos, KeyInfo{Key: key, TTL: ttl})
+	}
+	return infos, nil
+}
+
+func (m *MemoryCache) Stats(ctx context.Context) (Stats, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return Stats{
+		Name:   "memory",
+		Hits:   m.hits,
+		Misses: m.misses,
+		Keys:   len(m.items),
+	}, nil
+}
+
+func (m *MemoryCache) expired(item *memoryItem) bool {
+	return !item.expiresAt.IsZero() && !m.now().Before(item.expiresAt)
+}
+
+func (m *MemoryCache) remove(element *list.Element) {
+	m.order.Remove(element)
+	delete(m.items, element.Value.(*memoryItem).key)
+}
@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestMemoryCache(maxEntries int) (*MemoryCache, *time.Time) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := NewMemoryCache(maxEntries).(*MemoryCache)
+	m.now = func() time.Time { return now }
+	return m, &now
+}
+
+func TestMemoryCache_GetSet(t *testing.T) {
+	m, _ := newTestMemoryCache(10)
+	ctx := context.Background()
+
+	if _, err := m.Get(ctx, "launch:next"); !errors.Is(err, ErrMiss) {
+		t.Fatalf("expected ErrMiss, got %v", err)
+	}
+
+	m.Set(ctx, "launch:next", []byte("falcon"), time.Minute)
+
+	data, err := m.Get(ctx, "launch:next")
+	if err != nil || string(data) != "falcon" {
+		t.Fatalf("unexpected result %q, %v", data, err)
+	}
+}
+
+func TestMemoryCache_Expiry(t *testing.T) {
+	m, now := newTestMemoryCache(10)
+	ctx := context.Background()
+
+	m.Set(ctx, "short", []byte("a"), time.Minute)
+	m.Set(ctx, "forever", []byte("b"), 0)
+
+	*now = now.Add(time.Minute)
+
+	if _, err := m.Get(ctx, "short"); !errors.Is(err, ErrMiss) {
+		t.Fatalf("expected expired entry to miss, got %v", err)
+	}
+
+	if _, err := m.Get(ctx, "forever"); err != nil {
+		t.Fatalf("expected entry without ttl to survive, got %v", err)
+	}
+}
+
+func TestMemoryCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	m, _ := newTestMemoryCache(2)
+	ctx := context.Background()
+
+	m.Set(ctx, "a", []byte("a"), 0)
+	m.Set(ctx, "b", []byte("b"), 0)
+	m.Get(ctx, "a") // "b" is now the least recently used
+	m.Set(ctx, "c", []byte("c"), 0)
+
+	if _, err := m.Get(ctx, "b"); !errors.Is(err, ErrMiss) {
+		t.Fatalf("expected b to be evicted, got %v", err)
+	}
+
+	for _, key := range []string{"a", "c"} {
+		if _, err := m.Get(ctx, key); err != nil {
+			t.Fatalf("expected %s to be kept, got %v", key, err)
+		}
+	}
+}
+
+func TestMemoryCache_DeleteMatchingAndKeys(t *testing.T) {
+	m, now := newTestMemoryCache(10)
+	ctx := context.Background()
+
+	m.Set(ctx, "launch:next", []byte("next"), time.Minute)
+	m.Set(ctx, "launch:past:asc", []byte("asc"), 0)
+	m.Set(ctx, "launch:past:desc", []byte("desc"), time.Second)
+	m.Set(ctx, "other", []byte("other"), 0)
+
+	*now = now.Add(2 * time.Second) // launch:past:desc has expired
+
+	keys, err := m.Keys(ctx, "launch:*")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ttls := map[string]time.Duration{}
+	for _, key := range keys {
+		ttls[key.Key] = key.TTL
+	}
+	if len(ttls) != 2 || ttls["launch:next"] != 58*time.Second || ttls["launch:past:asc"] != -1 {
+		t.Fatalf("unexpected keys: %+v", keys)
+	}
+
+	if deleted, _ := m.DeleteMatching(ctx, "launch:past:*"); deleted != 2 {
+		t.Fatalf("expected 2 deleted, got %d", deleted)
+	}
+
+	m.Delete(ctx, "launch:next")
+
+	stats, _ := m.Stats(ctx)
+	if stats.Keys != 1 {
+		t.Fatalf("expected only the unrelated key left, got %+v", stats)
+	}
+}
@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// TieredCache checks a local (in-process) cache before a shared remote one.
+// Local copies live for at most localTTL so that replicas converge on what
+// the remote tier holds.
+type TieredCache struct {
+	local    Cache
+	remote   Cache
+	localTTL time.Duration
+}
+
+func NewTieredCache(local Cache, remote Cache, localTTL time.Duration) Cache {
+	return &TieredCache{
+		local:    local,
+		remote:   remote,
+		localTTL: localTTL,
+	}
+}
+
+func (t *TieredCache) Get(ctx context.Context, key string) ([]byte, error) {
+	if data, err := t.local.Get(ctx, key); err == nil {
+		return data, nil
+	}
+
+	data, err := t.remote.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = t.local.Set(ctx, key, data, t.localTTL)
+	return data, nil
+}
+
+func (t *TieredCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	localTTL := t.localTTL
+	if ttl > 0 && ttl < localTTL {
+		localTTL = ttl
+	}
+
+	_ = t.local.Set(ctx, key, value, localTTL)
+	return t.remote.Set(ctx, key, value, ttl)
+}
+
+func (t *TieredCache) Delete(ctx context.Context, key string) error {
+	return errors.Join(t.local.Delete(ctx, key), t.remote.Delete(ctx, key))
+}
+
+// DeleteMatching clears both tiers and reports how many keys the remote tier held.
+func (t *TieredCache) DeleteMatching(ctx context.Context, pattern string) (int, error) {
+	_, localErr := t.local.DeleteMatching(ctx, pattern)
+	deleted, remoteErr := t.remote.DeleteMatching(ctx, pattern)
+	return deleted, errors.Join(localErr, remoteErr)
+}
+
+// Keys lists the remote tier, which holds the authoritative TTLs.
+func (t *TieredCache) Keys(ctx context.Context, pattern string) ([]KeyInfo, error) {
+	return t.remote.Keys(ctx, pattern)
+}
+
+func (t *TieredCache) Stats(ctx context.Context) (Stats, error) {
+	local, err := t.local.Stats(ctx)
+	if err != nil {
+		return Stats{}, err
+	}
+
+	remote, err := t.remote.Stats(ctx)
+	if err != nil {
+		return Stats{}, err
+	}
+
+	return Stats{
+		Name:   "tiered",
+		Hits:   local.Hits + remote.Hits,
+		Misses: remote.Misses,
+		Keys:   remote.Keys,
+		Tiers:  []Stats{local, remote},
+	}, nil
+}
@@ -0,0 +1,76 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type recordingCache struct {
+	Cache
+	gets int
+	ttls map[string]time.Duration
+}
+
+func newRecordingCache() *recordingCache {
+	return &recordingCache{Cache: NewMemoryCache(10), ttls: map[string]time.Duration{}}
+}
+
+func (r *recordingCache) Get(ctx context.Context, key string) ([]byte, error) {
+	r.gets++
+	return r.Cache.Get(ctx, key)
+}
+
+func (r *recordingCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	r.ttls[key] = ttl
+	return r.Cache.Set(ctx, key, value, ttl)
+}
+
+func TestTieredCache_BackfillsLocalFromRemote(t *testing.T) {
+	local, remote := newRecordingCache(), newRecordingCache()
+	tiered := NewTieredCache(local, remote, 5*time.Second)
+	ctx := context.Background()
+
+	remote.Cache.Set(ctx, "launch:past:desc", []byte("past"), time.Hour)
+
+	for range 2 {
+		data, err := tiered.Get(ctx, "launch:past:desc")
+		if err != nil || string(data) != "past" {
+			t.Fatalf("unexpected result %q, %v", data, err)
+		}
+	}
+
+	if remote.gets != 1 {
+		t.Fatalf("expected remote to be read once, got %d", remote.gets)
+	}
+
+	if local.ttls["launch:past:desc"] != 5*time.Second {
+		t.Fatalf("expected local copy capped at 5s, got %v", local.ttls["launch:past:desc"])
+	}
+}
+
+func TestTieredCache_SetWritesBothTiers(t *testing.T) {
+	local, remote := newRecordingCache(), newRecordingCache()
+	tiered := NewTieredCache(local, remote, 5*time.Second)
+	ctx := context.Background()
+
+	tiered.Set(ctx, "launch:next", []byte("next"), 2*time.Second)
+	tiered.Set(ctx, "launch:latest", []byte("latest"), time.Minute)
+
+	if local.ttls["launch:next"] != 2*time.Second || remote.ttls["launch:next"] != 2*time.Second {
+		t.Fatalf("unexpected ttls for short entry: local %v remote %v", local.ttls["launch:next"], remote.ttls["launch:next"])
+	}
+
+	if local.ttls["launch:latest"] != 5*time.Second || remote.ttls["launch:latest"] != time.Minute {
+		t.Fatalf("unexpected ttls for long entry: local %v remote %v", local.ttls["launch:latest"], remote.ttls["launch:latest"])
+	}
+}
+
+func TestTieredCache_MissInBothTiers(t *testing.T) {
+	tiered := NewTieredCache(NewMemoryCache(1), NewMemoryCache(1), time.Second)
+
+	if _, err := tiered.Get(context.Background(), "missing"); !errors.Is(err, ErrMiss) {
+		t.Fatalf("expected ErrMiss, got %v", err)
+	}
+}
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// CacheSchemaVersion is bumped whenever the shape of a cached value changes,
+// e.g. a models field is renamed or retyped. Keys are namespaced by it, so a
+// deploy starts from an empty namespace instead of decoding what the previous
+// release wrote.
+const CacheSchemaVersion = 4
+
+// Compression is the algorithm applied to a cached payload.
+type Compression string
+
+const (
+	CompressionNone Compression = "none"
+	CompressionGzip Compression = "gzip"
+	CompressionZstd Compression = "zstd"
+)
+
+// ParseCompression validates a CACHE_COMPRESSION value.
+func ParseCompression(s string) (Compression, error) {
+	switch c := Compression(s); c {
+	case CompressionNone, CompressionGzip, CompressionZstd:
+		return c, nil
+	default:
+		return "", fmt.Errorf("unknown cache compression %q (want none, gzip or zstd)", s)
+	}
+}
+
+const codecJSON = "json"
+
+// minCompressSize is the payload size below which compression is skipped:
+// small documents such as a single launch barely shrink.
+const minCompressSize = 1024
+
+var errIncompatibleEntry = errors.New("incompatible cache entry")
+
+var (
+	zstdEncoder, _ = zstd.NewWriter(nil)
+	zstdDecoder, _ = zstd.NewReader(nil)
+)
+
+// VersionedKey namespaces a cache key by CacheSchemaVersion.
+func VersionedKey(key string) string {
+	return fmt.Sprintf("v%d:%s", CacheSchemaVersion, key)
+}
+
+// LogicalKey strips the CacheSchemaVersion namespace added by VersionedKey.
+func LogicalKey(key string) string {
+	return strings.TrimPrefix(key, VersionedKey(""))
+}
+
+// cacheEntry is the envelope stored under a cache key. The cache itself
+// expires it after the hard TTL (ttl + staleTTL); FreshUntil marks the soft TTL.
+//
+// It is stored as a JSON header line followed by the encoded payload, so
+// compressed data isn't inflated by base64.
+type cacheEntry struct {
+	Version     int         `json:"version"`
+	Codec       string      `json:"codec"`
+	Compression Compression `json:"compression"`
+	FetchedAt   time.Time   `json:"fetched_at"`
+	FreshUntil  time.Time   `json:"fresh_until"`
+	Source      string      `json:"source"`
+
+	// Error is set on negative entries, which carry no payload.
+	Error *cachedFailure `json:"error,omitempty"`
+}
+
+// marshalEntry encodes value and wraps it in entry. Version and Codec are
+// filled in; Compression is downgraded to none for small payloads.
+func marshalEntry(entry cacheEntry, value any) ([]byte, error) {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.Version = CacheSchemaVersion
+	entry.Codec = codecJSON
+	if entry.Compression == "" || len(payload) < minCompressSize {
+		entry.Compression = CompressionNone
+	}
+
+	payload, err = compress(entry.Compression, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := json.Marshal(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, 0, len(header)+1+len(payload))
+	data = append(data, header...)
+	data = append(data, '\n')
+	return append(data, payload...), nil
+}
+
+// marshalFailure builds a negative entry recording failure.
+func marshalFailure(entry cacheEntry, failure *cachedFailure) ([]byte, error) {
+	entry.Version = CacheSchemaVersion
+	entry.Codec = codecJSON
+	entry.Compression = CompressionNone
+	entry.Error = failure
+
+	header, err := json.Marshal(entry)
+	if err != nil {
+		return nil, err
+	}
+	return append(header, '\n'), nil
+}
+
+// unmarshalEntry decodes data written by marshalEntry into out. For negative
+// entries out is left untouched and entry.Error is set.
+func unmarshalEntry(data []byte, out any) (cacheEntry, error) {
+	entry, payload, err := unmarshalHeader(data)
+	if err != nil || entry.Error != nil {
+		return entry, err
+	}
+
+	payload, err = decompress(entry.Compression, payload)
+	if err != nil {
+		return entry, err
+	}
+	return entry, json.Unmarshal(payload, out)
+}
+
+// unmarshalHeader decodes only the envelope of data, leaving the payload
+// undecoded.
+func unmarshalHeader(data []byte) (cacheEntry, []byte, error) {
+	var entry cacheEntry
+
+	header, payload, ok := bytes.Cut(data, []byte{'\n'})
+	if !ok {
+		return entry, nil, fmt.Errorf("%w: missing header", errIncompatibleEntry)
+	}
+	if err := json.Unmarshal(header, &entry); err != nil {
+		return entry, nil, err
+	}
+	if entry.Version != CacheSchemaVersion || entry.Codec != codecJSON {
+		return entry, nil, fmt.Errorf("%w: version %d, codec %q", errIncompatibleEntry, entry.Version, entry.Codec)
+	}
+	return entry, payload, nil
+}
+
+func compress(c Compression, data []byte) ([]byte, error) {
+	switch c {
+	case CompressionNone:
+		return data, nil
+	case CompressionGzip:
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case CompressionZstd:
+		return zstdEncoder.EncodeAll(data, nil), nil
+	default:
+		return nil, fmt.Errorf("%w: compression %q", errIncompatibleEntry, c)
+	}
+}
+
+func decompress(c Compression, data []byte) ([]byte, error) {
+	switch c {
+	case CompressionNone:
+		return data, nil
+	case CompressionGzip:
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		return io.ReadAll(r)
+	case CompressionZstd:
+		return zstdDecoder.DecodeAll(data, nil)
+	default:
+		return nil, fmt.Errorf("%w: compression %q", errIncompatibleEntry, c)
+	}
+}
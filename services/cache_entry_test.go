@@ -0,0 +1,98 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"spacex-tracker/models"
+)
+
+func TestCacheEntry_RoundTrip(t *testing.T) {
+	launches := make([]models.Launch, 50)
+	for i := range launches {
+		launches[i] = models.Launch{Id: "5eb87cd9ffd86e000604b32a", Name: "FalconSat", Details: strings.Repeat("x", 40)}
+	}
+	fetchedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, c := range []Compression{CompressionNone, CompressionGzip, CompressionZstd} {
+		t.Run(string(c), func(t *testing.T) {
+			data, err := marshalEntry(cacheEntry{Compression: c, FetchedAt: fetchedAt, Source: "replica-a"}, launches)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []models.Launch
+			entry, err := unmarshalEntry(data, &got)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if entry.Version != CacheSchemaVersion || entry.Codec != "json" || entry.Compression != c {
+				t.Fatalf("unexpected envelope %+v", entry)
+			}
+			if !entry.FetchedAt.Equal(fetchedAt) || entry.Source != "replica-a" {
+				t.Fatalf("unexpected metadata %+v", entry)
+			}
+			if !reflect.DeepEqual(got, launches) {
+				t.Fatalf("unexpected payload %+v", got[0])
+			}
+		})
+	}
+}
+
+func TestCacheEntry_SmallPayloadsAreNotCompressed(t *testing.T) {
+	data, err := marshalEntry(cacheEntry{Compression: CompressionZstd}, models.Launch{Id: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got models.Launch
+	entry, err := unmarshalEntry(data, &got)
+	if err != nil || entry.Compression != CompressionNone || got.Id != "1" {
+		t.Fatalf("unexpected entry %+v, %+v, %v", entry, got, err)
+	}
+}
+
+func TestCacheEntry_RejectsOtherVersions(t *testing.T) {
+	data, err := marshalEntry(cacheEntry{}, "value")
+	if err != nil {
+		t.Fatal(err)
+	}
+	current := fmt.Sprintf(`"version":%d`, CacheSchemaVersion)
+	older := fmt.Sprintf(`"version":%d`, CacheSchemaVersion-1)
+	data = bytes.Replace(data, []byte(current), []byte(older), 1)
+
+	var got string
+	if _, err := unmarshalEntry(data, &got); !errors.Is(err, errIncompatibleEntry) {
+		t.Fatalf("expected errIncompatibleEntry, got %v", err)
+	}
+
+	// Values written before the envelope existed are plain JSON.
+	if _, err := unmarshalEntry([]byte(`{"fresh_until":"2024-01-01T00:00:00Z","data":"x"}`), &got); !errors.Is(err, errIncompatibleEntry) {
+		t.Fatalf("expected errIncompatibleEntry, got %v", err)
+	}
+}
+
+func TestCachedService_NamespacesKeysBySchemaVersion(t *testing.T) {
+	prefix := fmt.Sprintf("v%d:", CacheSchemaVersion)
+	if VersionedKey("launch:next") != prefix+"launch:next" || LaunchKeyPattern != prefix+"launch:*" {
+		t.Fatalf("unexpected keys %q, %q", VersionedKey("launch:next"), LaunchKeyPattern)
+	}
+	if LogicalKey(VersionedKey("launch:next")) != "launch:next" {
+		t.Fatalf("unexpected logical key %q", LogicalKey(VersionedKey("launch:next")))
+	}
+}
+
+func TestParseCompression(t *testing.T) {
+	if c, err := ParseCompression("zstd"); err != nil || c != CompressionZstd {
+		t.Fatalf("unexpected result %q, %v", c, err)
+	}
+	if _, err := ParseCompression("brotli"); err == nil {
+		t.Fatal("expected an error for an unknown compression")
+	}
+}
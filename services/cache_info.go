@@ -0,0 +1,35 @@
+package services
+
+import "context"
+
+type CacheStatus string
+
+const (
+	CacheHit   CacheStatus = "HIT"
+	CacheMiss  CacheStatus = "MISS"
+	CacheStale CacheStatus = "STALE"
+
+	// CacheNegative means a recent upstream failure was replayed from the cache.
+	CacheNegative CacheStatus = "NEGATIVE"
+)
+
+// CacheInfo describes how the cached service answered a request.
+// Status stays empty when no cache was involved.
+type CacheInfo struct {
+	Status CacheStatus
+}
+
+type cacheInfoKey struct{}
+
+// WithCacheInfo returns a context that collects cache information for a request.
+func WithCacheInfo(ctx context.Context) (context.Context, *CacheInfo) {
+	info := &CacheInfo{}
+	return context.WithValue(ctx, cacheInfoKey{}, info), info
+}
+
+// SetCacheStatus records status on the CacheInfo carried by ctx, if any.
+func SetCacheStatus(ctx context.Context, status CacheStatus) {
+	if info, ok := ctx.Value(cacheInfoKey{}).(*CacheInfo); ok {
+		info.Status = status
+	}
+}
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"strings"
+	"time"
+)
+
+// CacheMetrics receives cache events from the cached launch service, labelled
+// by cache key.
+type CacheMetrics interface {
+	// Lookup records how a request was answered: HIT, MISS, STALE or NEGATIVE.
+	Lookup(key string, status CacheStatus)
+	// Error records a cache failure during op: "get", "decode", "encode" or "set".
+	Error(key string, op string)
+	// Fetch records the latency and outcome of an upstream fetch.
+	Fetch(key string, duration time.Duration, err error)
+}
+
+type noopCacheMetrics struct{}
+
+func (noopCacheMetrics) Lookup(string, CacheStatus)         {}
+func (noopCacheMetrics) Error(string, string)               {}
+func (noopCacheMetrics) Fetch(string, time.Duration, error) {}
+
+// metricKey is the label recorded for key. Per-launch keys collapse into one
+// label per lookup kind, so requests for arbitrary IDs can't grow the number
+// of series.
+func metricKey(key string) string {
+	for _, prefix := range []string{keyLaunchID, keyFlight} {
+		if strings.HasPrefix(key, prefix) {
+			return prefix + "*"
+		}
+	}
+	return key
+}
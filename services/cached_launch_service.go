@@ -2,81 +2,478 @@ package services
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/singleflight"
+
+	"spacex-tracker/logging"
 	"spacex-tracker/services/cache"
 	"spacex-tracker/models"
 )
 
+// LaunchKeyPattern matches every cache key written by the cached launch service
+// under the current CacheSchemaVersion.
+var LaunchKeyPattern = VersionedKey("launch:*")
+
+const (
+	keyNext     = "launch:next"
+	keyLatest   = "launch:latest"
+	keyUpcoming = "launch:upcoming"
+	keyPast     = "launch:past"
+
+	// Single launches are cached per ID and per flight number.
+	keyLaunchID = "launch:id:"
+	keyFlight   = "launch:flight:"
+)
+
+var ErrUnknownEndpoint = errors.New("unknown endpoint")
+
+// Refresher forces a cached endpoint ("next", "latest", "upcoming" or "past")
+// to be re-fetched from upstream and rewritten in the cache.
+type Refresher interface {
+	Refresh(ctx context.Context, endpoint string) error
+}
+
+// endpointKeys maps the endpoints accepted by Refresh to their cache keys.
+var endpointKeys = map[string]string{
+	"next":     keyNext,
+	"latest":   keyLatest,
+	"upcoming": keyUpcoming,
+	"past":     keyPast,
+}
+
 type cachedLaunchService struct {
 	inner  LaunchService
 	cache cache.Cache
-	ttl   time.Duration
+	ttls  TTLPolicy
+
+	// shared is the tier every replica writes to, read by FreshUntil; nil
+	// when cache itself is shared or there is only one replica.
+	shared cache.Cache
+
+	// staleTTL is how long past its TTL an entry may still be served
+	// while it is refreshed in the background (stale-while-revalidate).
+	staleTTL time.Duration
+
+	// negativeTTL is how long an upstream failure is cached and replayed
+	// to later callers. Zero disables negative caching.
+	negativeTTL time.Duration
+
+	// flights coalesces concurrent cache misses for the same key into one fetch.
+	flights singleflight.Group
+
+	// failedRefreshes holds, per key, until when background refreshes are
+	// skipped after one failed, so an outage isn't retried on every request.
+	failedRefreshesMu sync.Mutex
+	failedRefreshes   map[string]time.Time
+
+	// background tracks refreshes that outlive the request that started them.
+	background *sync.WaitGroup
+
+	metrics CacheMetrics
+
+	// compression is applied to payloads before they are written; source
+	// records which replica fetched them.
+	compression Compression
+	source      string
+
+	now func() time.Time
+}
+
+type CacheOption func(*cachedLaunchService)
+
+// WithTTLPolicy overrides the uniform TTL passed to NewCachedLaunchService.
+func WithTTLPolicy(policy TTLPolicy) CacheOption {
+	return func(c *cachedLaunchService) {
+		c.ttls = policy
+	}
+}
+
+// WithMetrics reports hits, misses, cache failures and fetch latency to m.
+func WithMetrics(m CacheMetrics) CacheOption {
+	return func(c *cachedLaunchService) {
+		c.metrics = m
+	}
+}
+
+// WithNegativeTTL caches upstream failures (error statuses, timeouts and
+// malformed payloads) for d, so an outage isn't hit by every request.
+func WithNegativeTTL(d time.Duration) CacheOption {
+	return func(c *cachedLaunchService) {
+		c.negativeTTL = d
+	}
+}
+
+// WithCompression compresses cached payloads with the given algorithm.
+func WithCompression(c Compression) CacheOption {
+	return func(s *cachedLaunchService) {
+		s.compression = c
+	}
+}
+
+// WithSharedCache makes FreshUntil read from shared, the tier every replica
+// writes to, rather than through a local tier that may hold an older copy.
+func WithSharedCache(shared cache.Cache) CacheOption {
+	return func(c *cachedLaunchService) {
+		c.shared = shared
+	}
+}
+
+// WithBackgroundGroup adds the refreshes started in the background for stale
+// entries to wg, so that shutdown can wait for them before closing the cache.
+func WithBackgroundGroup(wg *sync.WaitGroup) CacheOption {
+	return func(c *cachedLaunchService) {
+		c.background = wg
+	}
+}
+
+// WithStaleTTL enables stale-while-revalidate: entries are refreshed in the
+// background once older than the TTL, and served stale for up to d more,
+// including while upstream is failing.
+func WithStaleTTL(d time.Duration) CacheOption {
+	return func(c *cachedLaunchService) {
+		c.staleTTL = d
+	}
 }
 
 func NewCachedLaunchService(
 	inner LaunchService,
 	cache cache.Cache,
 	ttl time.Duration,
+	opts ...CacheOption,
 ) LaunchService {
 	if cache == nil {
         panic("cache cannot be nil")
     }
-	return &cachedLaunchService{
+	source, _ := os.Hostname()
+	c := &cachedLaunchService{
 		inner: inner,
 		cache: cache,
-		ttl:   ttl,
+		ttls:  UniformTTL(ttl),
+		now:   time.Now,
+		metrics: noopCacheMetrics{},
+		compression: CompressionNone,
+		source: source,
+		failedRefreshes: make(map[string]time.Time),
+		background: &sync.WaitGroup{},
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
 }
 
 func getOrSet[T any](
     ctx context.Context, 
-    c cache.Cache, 
+    s *cachedLaunchService, 
     key string, 
-    ttl time.Duration, 
+    ttl func(T) time.Duration, 
     fetch func(context.Context) (T, error),
 ) (T, error) {
     // Attempt to retrieve from cache
-    if data, err := c.Get(ctx, key); err == nil {
+    data, err := s.cache.Get(ctx, VersionedKey(key))
+    switch {
+    case err == nil:
         var result T
-        if err := json.Unmarshal(data, &result); err == nil {
+        entry, err := unmarshalEntry(data, &result)
+        if err != nil {
+            s.cacheError(ctx, key, "decode", err)
+            break
+        }
+
+        if entry.Error != nil {
+            // Negative entry: replay the failure until it expires, then refetch.
+            if s.now().Before(entry.FreshUntil) {
+                s.lookup(ctx, key, CacheNegative)
+                var zero T
+                return zero, entry.Error.err()
+            }
+            break
+        }
+
+        if s.now().Before(entry.FreshUntil) {
+            s.lookup(ctx, key, CacheHit)
             return result, nil
         }
+
+        // A local tier may hold a copy after the shared tier expired it;
+        // past the stale window it is as good as missing.
+        if !s.now().Before(entry.FreshUntil.Add(s.staleTTL)) {
+            break
+        }
+
+        // Stale: answer now and refresh in the background. A failed refresh
+        // leaves the entry in place until the cache expires it, and holds
+        // back further refreshes for the negative TTL.
+        if s.refreshAllowed(key) {
+            flight := s.flights.DoChan(key, s.backgroundRefresh(key, load(context.WithoutCancel(ctx), s, key, ttl, fetch, false)))
+            s.background.Go(func() { <-flight })
+        }
+        s.lookup(ctx, key, CacheStale)
+        return result, nil
+    case !errors.Is(err, cache.ErrMiss):
+        s.cacheError(ctx, key, "get", err)
     }
 
-    // Cache miss: Fetch data via the provided function
-    result, err := fetch(ctx)
-    if err != nil {
+    // Cache miss: only one caller per key fetches, the others wait for its result.
+    // The fetch is detached from the leader's cancellation so that a leader giving
+    // up doesn't fail every waiter; each waiter still honours its own context.
+    // Only misses cache failures: a stale entry is better than a cached error.
+    s.lookup(ctx, key, CacheMiss)
+    flight := s.flights.DoChan(key, load(context.WithoutCancel(ctx), s, key, ttl, fetch, true))
+
+    select {
+    case <-ctx.Done():
         var zero T
-        return zero, err
+        return zero, ctx.Err()
+    case res := <-flight:
+        if res.Err != nil {
+            var zero T
+            return zero, res.Err
+        }
+        return res.Val.(T), nil
     }
+}
 
-    // Store in cache for future use
-    if bytes, err := json.Marshal(result); err == nil {
-        _ = c.Set(ctx, key, bytes, ttl)
+// refresh fetches key unconditionally and waits for it to be stored. Any
+// flight already running for key is forgotten so the result is newer than
+// the call to refresh.
+func refresh[T any](
+    ctx context.Context,
+    s *cachedLaunchService,
+    key string,
+    ttl func(T) time.Duration,
+    fetch func(context.Context) (T, error),
+) error {
+    s.flights.Forget(key)
+    flight := s.flights.DoChan(key, load(context.WithoutCancel(ctx), s, key, ttl, fetch, false))
+
+    select {
+    case <-ctx.Done():
+        return ctx.Err()
+    case res := <-flight:
+        return res.Err
     }
+}
 
-    return result, nil
+// load returns a singleflight function that fetches a value and stores it under key.
+// When negative is set, a failed fetch is cached for the negative TTL.
+func load[T any](
+    ctx context.Context,
+    s *cachedLaunchService,
+    key string,
+    ttl func(T) time.Duration,
+    fetch func(context.Context) (T, error),
+    negative bool,
+) func() (any, error) {
+    return func() (any, error) {
+        start := time.Now()
+        result, err := fetch(ctx)
+        s.metrics.Fetch(metricKey(key), time.Since(start), err)
+        if err != nil {
+            if negative {
+                s.storeFailure(ctx, key, err)
+            }
+            return nil, err
+        }
+
+        // Store in cache for future use
+        now := s.now()
+        fresh := ttl(result)
+        data, err := marshalEntry(cacheEntry{
+            Compression: s.compression,
+            FetchedAt:   now,
+            FreshUntil:  now.Add(fresh),
+            Source:      s.source,
+        }, result)
+        if err != nil {
+            s.cacheError(ctx, key, "encode", err)
+            return result, nil
+        }
+
+        if err := s.cache.Set(ctx, VersionedKey(key), data, fresh+s.staleTTL); err != nil {
+            s.cacheError(ctx, key, "set", err)
+        }
+
+        return result, nil
+    }
+}
+
+// backgroundRefresh wraps a stale-entry refresh so that a failure holds back
+// the next ones for key until the negative TTL has passed.
+func (c *cachedLaunchService) backgroundRefresh(key string, fn func() (any, error)) func() (any, error) {
+	return func() (any, error) {
+		result, err := fn()
+		if err != nil && c.negativeTTL > 0 {
+			c.failedRefreshesMu.Lock()
+			c.failedRefreshes[key] = c.now().Add(c.negativeTTL)
+			c.failedRefreshesMu.Unlock()
+		}
+		return result, err
+	}
+}
+
+// refreshAllowed reports whether a background refresh of key may start.
+func (c *cachedLaunchService) refreshAllowed(key string) bool {
+	c.failedRefreshesMu.Lock()
+	defer c.failedRefreshesMu.Unlock()
+
+	until, ok := c.failedRefreshes[key]
+	if !ok {
+		return true
+	}
+	if c.now().Before(until) {
+		return false
+	}
+	delete(c.failedRefreshes, key)
+	return true
+}
+
+// storeFailure caches err under key for the negative TTL, if it is worth caching.
+func (c *cachedLaunchService) storeFailure(ctx context.Context, key string, err error) {
+	failure, ok := newCachedFailure(err)
+	if !ok || c.negativeTTL <= 0 {
+		return
+	}
+
+	now := c.now()
+	data, err := marshalFailure(cacheEntry{
+		FetchedAt:  now,
+		FreshUntil: now.Add(c.negativeTTL),
+		Source:     c.source,
+	}, failure)
+	if err != nil {
+		c.cacheError(ctx, key, "encode", err)
+		return
+	}
+
+	if err := c.cache.Set(ctx, VersionedKey(key), data, c.negativeTTL); err != nil {
+		c.cacheError(ctx, key, "set", err)
+	}
+}
+
+// cacheError records a failed cache operation. The request is still served,
+// from upstream or without storing the result.
+func (c *cachedLaunchService) cacheError(ctx context.Context, key string, op string, err error) {
+	c.metrics.Error(metricKey(key), op)
+	logging.FromContext(ctx).Warn("cache operation failed", "key", key, "op", op, "error", err)
+}
+
+func (c *cachedLaunchService) lookup(ctx context.Context, key string, status CacheStatus) {
+	SetCacheStatus(ctx, status)
+	trace.SpanFromContext(ctx).SetAttributes(
+		attribute.String("cache.key", key),
+		attribute.String("cache.status", string(status)),
+	)
+	c.metrics.Lookup(metricKey(key), status)
 }
 
 func (c *cachedLaunchService) GetNext(ctx context.Context) (*models.Launch, error) {
-	return getOrSet(ctx, c.cache, "launch:next", c.ttl, c.inner.GetNext)
+	return getOrSet(ctx, c, keyNext, c.ttls.ForNext, c.inner.GetNext)
 }
 
 func (c *cachedLaunchService) GetLatest(ctx context.Context) (*models.Launch, error) {
-	return getOrSet(ctx, c.cache, "launch:latest", c.ttl, c.inner.GetLatest)
+	return getOrSet(ctx, c, keyLatest, c.ttls.ForLatest, c.inner.GetLatest)
 }
 
-func (c *cachedLaunchService) GetUpcoming(ctx context.Context) ([]models.Launch, error) {
-	return getOrSet(ctx, c.cache, "launch:upcoming", c.ttl, c.inner.GetUpcoming)
+// The list endpoints cache the full, unfiltered list so that every filter is
+// served from the same entry, and filter it on the way out. Past launches are
+// cached once, oldest first, and reversed on the way out for "desc".
+
+func (c *cachedLaunchService) GetUpcoming(ctx context.Context, filter LaunchFilter) ([]models.Launch, error) {
+	launches, err := getOrSet(ctx, c, keyUpcoming, c.ttls.ForUpcoming, c.fetchUpcoming)
+	if err != nil {
+		return nil, err
+	}
+	return filter.Apply(launches), nil
 }
 
-func (c *cachedLaunchService) GetPast(ctx context.Context, sortOrder string) ([]models.Launch, error) {
-	if sortOrder != "asc" {
-		sortOrder = "desc"
+func (c *cachedLaunchService) GetPast(ctx context.Context, sortOrder string, filter LaunchFilter) ([]models.Launch, error) {
+	launches, err := getOrSet(ctx, c, keyPast, c.ttls.ForPast, c.fetchPast)
+	if err != nil {
+		return nil, err
+	}
+
+	launches = filter.Apply(launches)
+	if strings.ToLower(sortOrder) == "asc" {
+		return launches, nil
 	}
-	
-	key := "launch:past"+sortOrder
-	return getOrSet(ctx, c.cache, key, c.ttl, c.inner.GetUpcoming)
-}
\ No newline at end of file
+
+	// A new slice, since launches may be the cached list itself.
+	reversed := make([]models.Launch, len(launches))
+	for i, launch := range launches {
+		reversed[len(launches)-1-i] = launch
+	}
+	return reversed, nil
+}
+
+func (c *cachedLaunchService) fetchUpcoming(ctx context.Context) ([]models.Launch, error) {
+	return c.inner.GetUpcoming(ctx, LaunchFilter{})
+}
+
+func (c *cachedLaunchService) fetchPast(ctx context.Context) ([]models.Launch, error) {
+	return c.inner.GetPast(ctx, "asc", LaunchFilter{})
+}
+
+func (c *cachedLaunchService) GetByID(ctx context.Context, id string) (*models.Launch, error) {
+	return getOrSet(ctx, c, keyLaunchID+id, c.ttls.ForLaunch, func(ctx context.Context) (*models.Launch, error) {
+		return c.inner.GetByID(ctx, id)
+	})
+}
+
+func (c *cachedLaunchService) GetByFlightNumber(ctx context.Context, flightNumber int) (*models.Launch, error) {
+	return getOrSet(ctx, c, keyFlight+strconv.Itoa(flightNumber), c.ttls.ForLaunch, func(ctx context.Context) (*models.Launch, error) {
+		return c.inner.GetByFlightNumber(ctx, flightNumber)
+	})
+}
+
+// FreshUntil reports when endpoint's cached entry stops being fresh, as seen
+// by every replica. It is the zero time when nothing usable is cached, a
+// cached failure included.
+func (c *cachedLaunchService) FreshUntil(ctx context.Context, endpoint string) (time.Time, error) {
+	key, ok := endpointKeys[endpoint]
+	if !ok {
+		return time.Time{}, fmt.Errorf("%w: %q", ErrUnknownEndpoint, endpoint)
+	}
+
+	store := c.cache
+	if c.shared != nil {
+		store = c.shared
+	}
+	data, err := store.Get(ctx, VersionedKey(key))
+	if errors.Is(err, cache.ErrMiss) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	entry, _, err := unmarshalHeader(data)
+	if err != nil || entry.Error != nil {
+		return time.Time{}, nil
+	}
+	return entry.FreshUntil, nil
+}
+
+func (c *cachedLaunchService) Refresh(ctx context.Context, endpoint string) error {
+	switch endpoint {
+	case "next":
+		return refresh(ctx, c, keyNext, c.ttls.ForNext, c.inner.GetNext)
+	case "latest":
+		return refresh(ctx, c, keyLatest, c.ttls.ForLatest, c.inner.GetLatest)
+	case "upcoming":
+		return refresh(ctx, c, keyUpcoming, c.ttls.ForUpcoming, c.fetchUpcoming)
+	case "past":
+		return refresh(ctx, c, keyPast, c.ttls.ForPast, c.fetchPast)
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownEndpoint, endpoint)
+	}
+}
@@ -2,38 +2,90 @@ package services
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	"spacex-tracker/models"
+	"spacex-tracker/services/cache"
 )
 
 type mockCache struct {
+	mu        sync.Mutex
 	getData   []byte
 	getErr    error
 	setCalled bool
 	setValue  []byte
 	setKey    string
+	setTTL    time.Duration
 }
 
 func (m *mockCache) Get(ctx context.Context, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	return m.getData, m.getErr
 }
 
 func (m *mockCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.setCalled = true
 	m.setValue = value
 	m.setKey = key
+	m.setTTL = ttl
+	return nil
+}
+
+func (m *mockCache) Delete(ctx context.Context, key string) error {
 	return nil
 }
 
+func (m *mockCache) DeleteMatching(ctx context.Context, pattern string) (int, error) {
+	return 0, nil
+}
+
+func (m *mockCache) Keys(ctx context.Context, pattern string) ([]cache.KeyInfo, error) {
+	return nil, nil
+}
+
+func (m *mockCache) Stats(ctx context.Context) (cache.Stats, error) {
+	return cache.Stats{}, nil
+}
+
+func (m *mockCache) wasSet() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.setCalled
+}
+
+func fixedTTL[T any](ttl time.Duration) func(T) time.Duration {
+	return func(T) time.Duration { return ttl }
+}
+
+// encodeEntry builds a cached value the way getOrSet stores it.
+func encodeEntry(t *testing.T, value any, freshUntil time.Time) []byte {
+	t.Helper()
+
+	bytes, err := marshalEntry(cacheEntry{FreshUntil: freshUntil}, value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bytes
+}
+
+func newTestCachedService(c cache.Cache) *cachedLaunchService {
+	return NewCachedLaunchService(nil, c, time.Minute).(*cachedLaunchService)
+}
+
 func TestGetOrSet_CacheHit(t *testing.T) {
 	type testData struct {
 		Name string
 	}
 
 	expected := testData{Name: "Falcon 9"}
-	bytes, _ := json.Marshal(expected)
+	bytes := encodeEntry(t, expected, time.Now().Add(time.Minute))
 
 	cache := &mockCache{
 		getData: bytes,
@@ -44,9 +96,9 @@ func TestGetOrSet_CacheHit(t *testing.T) {
 
 	result, err := getOrSet(
 		context.Background(),
-		cache,
+		newTestCachedService(cache),
 		"key",
-		time.Minute,
+		fixedTTL[testData](time.Minute),
 		func(ctx context.Context) (testData, error) {
 			fetchCalled = true
 			return testData{}, nil
@@ -79,9 +131,9 @@ func TestGetOrSet_CacheMiss(t *testing.T) {
 
 	result, err := getOrSet(
 		context.Background(),
-		cache,
+		newTestCachedService(cache),
 		"key",
-		time.Minute,
+		fixedTTL[testData](time.Minute),
 		func(ctx context.Context) (testData, error) {
 			fetchCalled = true
 			return testData{Name: "Starship"}, nil
@@ -112,9 +164,9 @@ func TestGetOrSet_FetchError(t *testing.T) {
 
 	result, err := getOrSet(
 		context.Background(),
-		cache,
+		newTestCachedService(cache),
 		"key",
-		time.Minute,
+		fixedTTL[string](time.Minute),
 		func(ctx context.Context) (string, error) {
 			return "", errors.New("fetch failed")
 		},
@@ -147,9 +199,9 @@ func TestGetOrSet_InvalidCacheData(t *testing.T) {
 
 	result, err := getOrSet(
 		context.Background(),
-		cache,
+		newTestCachedService(cache),
 		"key",
-		time.Minute,
+		fixedTTL[testData](time.Minute),
 		func(ctx context.Context) (testData, error) {
 			fetchCalled = true
 			return testData{Name: "Recovered"}, nil
@@ -167,4 +219,444 @@ func TestGetOrSet_InvalidCacheData(t *testing.T) {
 	if result.Name != "Recovered" {
 		t.Fatal("unexpected result")
 	}
-}
\ No newline at end of file
+}
+
+func TestGetOrSet_CoalescesConcurrentMisses(t *testing.T) {
+	svc := newTestCachedService(&mockCache{getErr: errors.New("miss")})
+
+	var fetches atomic.Int32
+	release := make(chan struct{})
+
+	fetch := func(ctx context.Context) (string, error) {
+		fetches.Add(1)
+		<-release
+		return "Falcon Heavy", nil
+	}
+
+	const callers = 10
+	var started, done sync.WaitGroup
+	results := make([]string, callers)
+
+	for i := range callers {
+		started.Add(1)
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			started.Done()
+			results[i], _ = getOrSet(context.Background(), svc, "key", fixedTTL[string](time.Minute), fetch)
+		}()
+	}
+
+	started.Wait()
+	time.Sleep(20 * time.Millisecond) // let every caller join the flight
+	close(release)
+	done.Wait()
+
+	if fetches.Load() != 1 {
+		t.Fatalf("expected 1 fetch, got %d", fetches.Load())
+	}
+
+	for i, result := range results {
+		if result != "Falcon Heavy" {
+			t.Fatalf("caller %d got unexpected result %q", i, result)
+		}
+	}
+}
+
+func TestGetOrSet_CoalescedWaiterHonoursOwnContext(t *testing.T) {
+	svc := newTestCachedService(&mockCache{getErr: errors.New("miss")})
+
+	release := make(chan struct{})
+	defer close(release)
+
+	fetch := func(ctx context.Context) (string, error) {
+		<-release
+		return "Dragon", nil
+	}
+
+	go getOrSet(context.Background(), svc, "key", fixedTTL[string](time.Minute), fetch)
+	time.Sleep(10 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := getOrSet(ctx, svc, "key", fixedTTL[string](time.Minute), fetch)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context deadline error, got %v", err)
+	}
+}
+
+type stubLaunchService struct {
+	LaunchService
+
+	pastSort string
+	past     []models.Launch
+}
+
+func (s *stubLaunchService) GetPast(ctx context.Context, sortOrder string, filter LaunchFilter) ([]models.Launch, error) {
+	s.pastSort = sortOrder
+	return s.past, nil
+}
+
+func TestCachedGetPast_FetchesPastLaunches(t *testing.T) {
+	inner := &stubLaunchService{past: []models.Launch{{Id: "past-1"}}}
+	mc := &mockCache{getErr: errors.New("miss")}
+	svc := NewCachedLaunchService(inner, mc, time.Minute)
+
+	result, err := svc.GetPast(context.Background(), "asc", LaunchFilter{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result) != 1 || result[0].Id != "past-1" || inner.pastSort != "asc" {
+		t.Fatalf("unexpected result %+v (sort %q)", result, inner.pastSort)
+	}
+
+	if mc.setKey != VersionedKey(keyPast) {
+		t.Fatalf("unexpected cache key: %s", mc.setKey)
+	}
+}
+
+func TestCachedGetPast_BothOrdersShareOneEntry(t *testing.T) {
+	calls := 0
+	client := &MockSpaceXClient{
+		GetPastFunc: func(ctx context.Context) ([]models.Launch, error) {
+			calls++
+			return []models.Launch{
+				{Id: "b", DateUTC: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+				{Id: "a", DateUTC: time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)},
+				{Id: "c", DateUTC: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+			}, nil
+		},
+	}
+	svc := NewCachedLaunchService(NewBaseLaunchService(client), cache.NewMemoryCache(10), time.Minute)
+
+	desc, _ := svc.GetPast(context.Background(), "desc", LaunchFilter{})
+	asc, _ := svc.GetPast(context.Background(), "asc", LaunchFilter{})
+	again, _ := svc.GetPast(context.Background(), "desc", LaunchFilter{})
+
+	ids := func(launches []models.Launch) string {
+		var s string
+		for _, launch := range launches {
+			s += launch.Id
+		}
+		return s
+	}
+
+	if ids(desc) != "cba" || ids(asc) != "abc" || ids(again) != "cba" {
+		t.Fatalf("unexpected orders desc=%s asc=%s desc=%s", ids(desc), ids(asc), ids(again))
+	}
+	if calls != 1 {
+		t.Fatalf("expected one upstream fetch for both orders, got %d", calls)
+	}
+}
+
+func TestGetOrSet_StaleServedWhileRefreshing(t *testing.T) {
+	mc := &mockCache{getData: encodeEntry(t, "old", time.Now().Add(-time.Second))}
+	svc := NewCachedLaunchService(nil, mc, time.Minute, WithStaleTTL(time.Hour)).(*cachedLaunchService)
+
+	refreshed := make(chan struct{})
+	ctx, info := WithCacheInfo(context.Background())
+
+	result, err := getOrSet(ctx, svc, "key", fixedTTL[string](time.Minute), func(ctx context.Context) (string, error) {
+		defer close(refreshed)
+		return "new", nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result != "old" || info.Status != CacheStale {
+		t.Fatalf("expected stale value, got %q (%s)", result, info.Status)
+	}
+
+	select {
+	case <-refreshed:
+	case <-time.After(time.Second):
+		t.Fatal("expected a background refresh")
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for !mc.wasSet() && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	if mc.setTTL != time.Minute+time.Hour {
+		t.Fatalf("expected hard TTL of 1h1m, got %v", mc.setTTL)
+	}
+}
+
+func TestGetOrSet_StaleServedWhileUpstreamFails(t *testing.T) {
+	mc := &mockCache{getData: encodeEntry(t, "old", time.Now().Add(-time.Second))}
+	svc := NewCachedLaunchService(nil, mc, time.Minute, WithStaleTTL(time.Hour)).(*cachedLaunchService)
+
+	failing := func(ctx context.Context) (string, error) {
+		return "", errors.New("upstream down")
+	}
+
+	for range 3 {
+		result, err := getOrSet(context.Background(), svc, "key", fixedTTL[string](time.Minute), failing)
+		if err != nil || result != "old" {
+			t.Fatalf("expected stale value, got %q, %v", result, err)
+		}
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	if mc.wasSet() {
+		t.Fatal("failed refresh must not overwrite the entry")
+	}
+}
+
+func TestGetOrSet_FailedRefreshHoldsBackFurtherRefreshes(t *testing.T) {
+	clock := time.Now()
+	mc := &mockCache{getData: encodeEntry(t, "old", clock.Add(-time.Second))}
+	svc := NewCachedLaunchService(nil, mc, time.Minute, WithStaleTTL(time.Hour), WithNegativeTTL(5*time.Second)).(*cachedLaunchService)
+	svc.now = func() time.Time { return clock }
+
+	var calls atomic.Int32
+	failing := func(ctx context.Context) (string, error) {
+		calls.Add(1)
+		return "", errors.New("upstream down")
+	}
+	held := func() bool {
+		svc.failedRefreshesMu.Lock()
+		defer svc.failedRefreshesMu.Unlock()
+		_, ok := svc.failedRefreshes["key"]
+		return ok
+	}
+	serve := func() {
+		result, err := getOrSet(context.Background(), svc, "key", fixedTTL[string](time.Minute), failing)
+		if err != nil || result != "old" {
+			t.Fatalf("expected stale value, got %q, %v", result, err)
+		}
+	}
+
+	serve()
+	deadline := time.Now().Add(time.Second)
+	for !held() && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	for range 3 {
+		serve()
+	}
+	time.Sleep(10 * time.Millisecond)
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("expected 1 upstream call during the hold-back, got %d", n)
+	}
+
+	clock = clock.Add(5 * time.Second)
+	serve()
+	deadline = time.Now().Add(time.Second)
+	for calls.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Fatalf("expected a retry once the negative TTL passed, got %d calls", n)
+	}
+}
+
+func TestGetOrSet_BackgroundRefreshJoinsGroup(t *testing.T) {
+	var wg sync.WaitGroup
+	mc := &mockCache{getData: encodeEntry(t, "old", time.Now().Add(-time.Second))}
+	svc := NewCachedLaunchService(nil, mc, time.Minute, WithStaleTTL(time.Hour), WithBackgroundGroup(&wg)).(*cachedLaunchService)
+
+	release := make(chan struct{})
+	getOrSet(context.Background(), svc, "key", fixedTTL[string](time.Minute), func(ctx context.Context) (string, error) {
+		<-release
+		return "new", nil
+	})
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("group finished before the refresh did")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	close(release)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("group did not finish after the refresh")
+	}
+}
+
+func TestGetOrSet_ExpiredCopyIsAMiss(t *testing.T) {
+	// A local tier can outlive the shared tier's TTL; without a stale window
+	// an entry past FreshUntil must not be served.
+	mc := &mockCache{getData: encodeEntry(t, "old", time.Now().Add(-time.Second))}
+	ctx, info := WithCacheInfo(context.Background())
+
+	result, err := getOrSet(ctx, newTestCachedService(mc), "key", fixedTTL[string](time.Minute), func(ctx context.Context) (string, error) {
+		return "new", nil
+	})
+	if err != nil || result != "new" || info.Status != CacheMiss {
+		t.Fatalf("expected a fresh fetch, got %q, %v (%s)", result, err, info.Status)
+	}
+}
+
+func TestGetOrSet_FreshHitReportsStatus(t *testing.T) {
+	mc := &mockCache{getData: encodeEntry(t, "value", time.Now().Add(time.Minute))}
+	ctx, info := WithCacheInfo(context.Background())
+
+	getOrSet(ctx, newTestCachedService(mc), "key", fixedTTL[string](time.Minute), func(ctx context.Context) (string, error) {
+		t.Fatal("fetch should not be called on a fresh hit")
+		return "", nil
+	})
+
+	if info.Status != CacheHit {
+		t.Fatalf("expected HIT, got %s", info.Status)
+	}
+}
+
+func TestRefresh_BypassesFreshEntry(t *testing.T) {
+	inner := &stubLaunchService{past: []models.Launch{{Id: "new"}}}
+	mc := &mockCache{getData: encodeEntry(t, []models.Launch{{Id: "old"}}, time.Now().Add(time.Hour))}
+	svc := NewCachedLaunchService(inner, mc, time.Minute).(Refresher)
+
+	if err := svc.Refresh(context.Background(), "past"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !mc.wasSet() || inner.pastSort == "" {
+		t.Fatal("expected past launches to be re-fetched and stored")
+	}
+}
+
+func TestRefresh_UnknownEndpoint(t *testing.T) {
+	svc := NewCachedLaunchService(nil, &mockCache{}, time.Minute).(Refresher)
+
+	if err := svc.Refresh(context.Background(), "rockets"); !errors.Is(err, ErrUnknownEndpoint) {
+		t.Fatalf("expected ErrUnknownEndpoint, got %v", err)
+	}
+}
+
+func TestFreshUntil_ReadsEntryHeader(t *testing.T) {
+	freshUntil := time.Now().Add(time.Hour).Truncate(time.Second)
+	mc := &mockCache{getData: encodeEntry(t, []models.Launch{{Id: "a"}}, freshUntil)}
+	svc := NewCachedLaunchService(nil, mc, time.Minute).(Warmable)
+
+	got, err := svc.FreshUntil(context.Background(), "past")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got.Equal(freshUntil) {
+		t.Fatalf("expected %v, got %v", freshUntil, got)
+	}
+}
+
+func TestFreshUntil_ReadsSharedTier(t *testing.T) {
+	local := &mockCache{getData: encodeEntry(t, []models.Launch{{Id: "a"}}, time.Now().Add(-time.Second))}
+	freshUntil := time.Now().Add(time.Hour).Truncate(time.Second)
+	shared := &mockCache{getData: encodeEntry(t, []models.Launch{{Id: "b"}}, freshUntil)}
+	svc := NewCachedLaunchService(nil, local, time.Minute, WithSharedCache(shared)).(Warmable)
+
+	got, err := svc.FreshUntil(context.Background(), "next")
+	if err != nil || !got.Equal(freshUntil) {
+		t.Fatalf("expected %v from the shared tier, got %v, %v", freshUntil, got, err)
+	}
+}
+
+func TestFreshUntil_ZeroWhenNothingUsableIsCached(t *testing.T) {
+	for name, mc := range map[string]*mockCache{
+		"miss":    {getErr: cache.ErrMiss},
+		"invalid": {getData: []byte("invalid-json")},
+	} {
+		svc := NewCachedLaunchService(nil, mc, time.Minute).(Warmable)
+
+		got, err := svc.FreshUntil(context.Background(), "next")
+		if err != nil || !got.IsZero() {
+			t.Fatalf("%s: expected zero time, got %v, %v", name, got, err)
+		}
+	}
+}
+
+type recordingMetrics struct {
+	mu      sync.Mutex
+	keys    []string
+	lookups []CacheStatus
+	errors  []string
+	fetches int
+}
+
+func (r *recordingMetrics) Lookup(key string, status CacheStatus) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.keys = append(r.keys, key)
+	r.lookups = append(r.lookups, status)
+}
+
+func (r *recordingMetrics) Error(key string, op string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errors = append(r.errors, op)
+}
+
+func (r *recordingMetrics) Fetch(key string, duration time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.fetches++
+}
+
+func TestGetOrSet_RecordsCacheFailures(t *testing.T) {
+	metrics := &recordingMetrics{}
+	mc := &mockCache{getData: []byte("invalid-json")}
+	svc := NewCachedLaunchService(nil, mc, time.Minute, WithMetrics(metrics)).(*cachedLaunchService)
+
+	getOrSet(context.Background(), svc, "key", fixedTTL[string](time.Minute), func(ctx context.Context) (string, error) {
+		return "fresh", nil
+	})
+
+	mc.getData, mc.getErr = nil, errors.New("redis down")
+	getOrSet(context.Background(), svc, "key", fixedTTL[string](time.Minute), func(ctx context.Context) (string, error) {
+		return "fresh", nil
+	})
+
+	if len(metrics.errors) != 2 || metrics.errors[0] != "decode" || metrics.errors[1] != "get" {
+		t.Fatalf("unexpected errors recorded: %v", metrics.errors)
+	}
+
+	if metrics.fetches != 2 || len(metrics.lookups) != 2 || metrics.lookups[0] != CacheMiss {
+		t.Fatalf("unexpected lookups %v / fetches %d", metrics.lookups, metrics.fetches)
+	}
+}
+
+func TestCachedGetByID_CachedPerIDWithBoundedMetricLabels(t *testing.T) {
+	var calls []string
+	client := &MockSpaceXClient{
+		GetLaunchFunc: func(ctx context.Context, id string) (*models.Launch, error) {
+			calls = append(calls, id)
+			return &models.Launch{Id: id}, nil
+		},
+	}
+	metrics := &recordingMetrics{}
+	svc := NewCachedLaunchService(NewBaseLaunchService(client), cache.NewMemoryCache(10), time.Minute, WithMetrics(metrics))
+
+	for _, id := range []string{"5eb87cd9ffd86e000604b32a", "5eb87cdaffd86e000604b32b", "5eb87cd9ffd86e000604b32a"} {
+		launch, err := svc.GetByID(context.Background(), id)
+		if err != nil || launch.Id != id {
+			t.Fatalf("unexpected result %+v, %v", launch, err)
+		}
+	}
+
+	if len(calls) != 2 {
+		t.Fatalf("expected one upstream call per ID, got %v", calls)
+	}
+
+	for _, key := range metrics.keys {
+		if key != "launch:id:*" {
+			t.Fatalf("expected every lookup under one label, got %v", metrics.keys)
+		}
+	}
+	if len(metrics.lookups) != 3 || metrics.lookups[2] != CacheHit {
+		t.Fatalf("unexpected lookups %v", metrics.lookups)
+	}
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"strings"
+	"time"
+
+	"spacex-tracker/models"
+)
+
+// Values accepted for LaunchFilter.Success.
+const (
+	SuccessAny     = ""
+	SuccessTrue    = "true"
+	SuccessFalse   = "false"
+	SuccessUnknown = "unknown"
+)
+
+// LaunchFilter selects launches from a list. Zero fields match everything.
+type LaunchFilter struct {
+	// From and To bound DateUTC, both inclusive.
+	From time.Time
+	To   time.Time
+
+	// Success is "true", "false" or "unknown" (no outcome recorded yet).
+	Success string
+
+	// Name matches a case-insensitive substring of the launch name.
+	Name string
+
+	Rocket    string
+	Launchpad string
+}
+
+// IsZero reports whether f matches every launch.
+func (f LaunchFilter) IsZero() bool {
+	return f == LaunchFilter{}
+}
+
+// Match reports whether launch satisfies every field set in f.
+func (f LaunchFilter) Match(launch models.Launch) bool {
+	if !f.From.IsZero() && launch.DateUTC.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && launch.DateUTC.After(f.To) {
+		return false
+	}
+
+	switch f.Success {
+	case SuccessTrue:
+		if launch.Success == nil || !*launch.Success {
+			return false
+		}
+	case SuccessFalse:
+		if launch.Success == nil || *launch.Success {
+			return false
+		}
+	case SuccessUnknown:
+		if launch.Success != nil {
+			return false
+		}
+	}
+
+	if f.Name != "" && !strings.Contains(strings.ToLower(launch.Name), strings.ToLower(f.Name)) {
+		return false
+	}
+	if f.Rocket != "" && launch.Rocket != f.Rocket {
+		return false
+	}
+	if f.Launchpad != "" && launch.Launchpad != f.Launchpad {
+		return false
+	}
+	return true
+}
+
+// Apply returns the launches matching f, in order. It never modifies
+// launches, which may be shared with the cache or other callers.
+func (f LaunchFilter) Apply(launches []models.Launch) []models.Launch {
+	if f.IsZero() {
+		return launches
+	}
+
+	matched := make([]models.Launch, 0, len(launches))
+	for _, launch := range launches {
+		if f.Match(launch) {
+			matched = append(matched, launch)
+		}
+	}
+	return matched
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"spacex-tracker/models"
+	"spacex-tracker/services/cache"
+)
+
+const (
+	falcon9   = "5e9d0d95eda69973a809d1ec"
+	falconHvy = "5e9d0d95eda69974db09d1ed"
+	slc40     = "5e9e4501f509094ba4566f84"
+)
+
+func filterFixture() []models.Launch {
+	succeeded, failed := true, false
+	return []models.Launch{
+		{Id: "a", Name: "Starlink 4-1", DateUTC: time.Date(2021, 11, 13, 12, 0, 0, 0, time.UTC), Success: &succeeded, Rocket: falcon9, Launchpad: slc40},
+		{Id: "b", Name: "FalconSat", DateUTC: time.Date(2006, 3, 24, 22, 30, 0, 0, time.UTC), Success: &failed, Rocket: falconHvy},
+		{Id: "c", Name: "Starlink 9-9", DateUTC: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), Rocket: falcon9, Launchpad: slc40},
+	}
+}
+
+func ids(launches []models.Launch) []string {
+	result := make([]string, len(launches))
+	for i, launch := range launches {
+		result[i] = launch.Id
+	}
+	return result
+}
+
+func TestLaunchFilter_Apply(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter LaunchFilter
+		want   []string
+	}{
+		{"zero", LaunchFilter{}, []string{"a", "b", "c"}},
+		{"from", LaunchFilter{From: time.Date(2021, 11, 13, 12, 0, 0, 0, time.UTC)}, []string{"a", "c"}},
+		{"to", LaunchFilter{To: time.Date(2021, 11, 13, 12, 0, 0, 0, time.UTC)}, []string{"a", "b"}},
+		{"success true", LaunchFilter{Success: SuccessTrue}, []string{"a"}},
+		{"success false", LaunchFilter{Success: SuccessFalse}, []string{"b"}},
+		{"success unknown", LaunchFilter{Success: SuccessUnknown}, []string{"c"}},
+		{"name is case-insensitive", LaunchFilter{Name: "STARLINK"}, []string{"a", "c"}},
+		{"rocket", LaunchFilter{Rocket: falconHvy}, []string{"b"}},
+		{"launchpad", LaunchFilter{Launchpad: slc40}, []string{"a", "c"}},
+		{"combined", LaunchFilter{Name: "starlink", Success: SuccessTrue, Launchpad: slc40}, []string{"a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ids(tt.filter.Apply(filterFixture()))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestLaunchFilter_ApplyDoesNotModifyInput(t *testing.T) {
+	launches := filterFixture()
+
+	matched := LaunchFilter{Success: SuccessUnknown}.Apply(launches)
+	matched[0].Name = "changed"
+
+	if !reflect.DeepEqual(ids(launches), []string{"a", "b", "c"}) || launches[2].Name != "Starlink 9-9" {
+		t.Fatalf("input was modified: %+v", launches)
+	}
+}
+
+func TestLaunchFilter_SameWithAndWithoutCache(t *testing.T) {
+	client := &MockSpaceXClient{
+		GetUpcomingFunc: func(ctx context.Context) ([]models.Launch, error) { return filterFixture(), nil },
+		GetPastFunc:     func(ctx context.Context) ([]models.Launch, error) { return filterFixture(), nil },
+	}
+	base := NewBaseLaunchService(client)
+	cached := NewCachedLaunchService(base, cache.NewMemoryCache(100), time.Minute)
+
+	filters := []LaunchFilter{
+		{},
+		{Name: "starlink"},
+		{Success: SuccessFalse},
+		{Rocket: falcon9, From: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	ctx := context.Background()
+	for _, filter := range filters {
+		// Run twice so the second pass is served from the cache.
+		for pass := 0; pass < 2; pass++ {
+			want, _ := base.GetUpcoming(ctx, filter)
+			got, err := cached.GetUpcoming(ctx, filter)
+			if err != nil || !reflect.DeepEqual(ids(got), ids(want)) {
+				t.Fatalf("upcoming %+v: expected %v, got %v (%v)", filter, ids(want), ids(got), err)
+			}
+
+			want, _ = base.GetPast(ctx, "asc", filter)
+			got, err = cached.GetPast(ctx, "asc", filter)
+			if err != nil || !reflect.DeepEqual(ids(got), ids(want)) {
+				t.Fatalf("past %+v: expected %v, got %v (%v)", filter, ids(want), ids(got), err)
+			}
+		}
+	}
+}
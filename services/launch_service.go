@@ -2,18 +2,49 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"slices"
 	"strings"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
 	"spacex-tracker/clients"
 	"spacex-tracker/models"
 )
 
+const tracerName = "spacex-tracker/services"
+
 type LaunchService interface {
 	GetNext(ctx context.Context) (*models.Launch, error)
 	GetLatest(ctx context.Context) (*models.Launch, error)
-	GetUpcoming(ctx context.Context) ([]models.Launch, error)
-	GetPast(ctx context.Context, sortOrder string) ([]models.Launch, error)
+	GetUpcoming(ctx context.Context, filter LaunchFilter) ([]models.Launch, error)
+	GetPast(ctx context.Context, sortOrder string, filter LaunchFilter) ([]models.Launch, error)
+	GetByID(ctx context.Context, id string) (*models.Launch, error)
+	GetByFlightNumber(ctx context.Context, flightNumber int) (*models.Launch, error)
+}
+
+// LaunchNotFoundError is returned when no launch has the requested ID or
+// flight number. It matches clients.ErrNotFound with errors.Is.
+type LaunchNotFoundError struct {
+	// Lookup describes what was looked up, e.g. "flight number 42".
+	Lookup string
+
+	cause error
+}
+
+func (e *LaunchNotFoundError) Error() string {
+	return "launch not found: " + e.Lookup
+}
+
+func (e *LaunchNotFoundError) Is(target error) bool {
+	return target == clients.ErrNotFound
+}
+
+func (e *LaunchNotFoundError) Unwrap() error {
+	return e.cause
 }
 
 type baseLaunchService struct {
@@ -34,27 +65,59 @@ func (s *baseLaunchService) GetLatest(ctx context.Context) (*models.Launch, erro
 	return s.client.GetLatest(ctx)
 }
 
-func (s *baseLaunchService) GetUpcoming(ctx context.Context) ([]models.Launch, error) {
-	return s.client.GetUpcoming(ctx)
+func (s *baseLaunchService) GetUpcoming(ctx context.Context, filter LaunchFilter) ([]models.Launch, error) {
+	launches, err := s.client.GetUpcoming(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return filter.Apply(launches), nil
 }
 
-func (s *baseLaunchService) GetPast(ctx context.Context, sortOrder string) ([]models.Launch, error) {
+func (s *baseLaunchService) GetPast(ctx context.Context, sortOrder string, filter LaunchFilter) ([]models.Launch, error) {
 	launches, err := s.client.GetPast(ctx)
 	if err != nil {
 		return nil, err
 	}
+	launches = filter.Apply(launches)
 
 	sortOrder = strings.ToLower(sortOrder)
 	if sortOrder != "asc" {
 		sortOrder = "desc"
 	}
 
+	_, span := otel.Tracer(tracerName).Start(ctx, "sort launches", trace.WithAttributes(
+		attribute.String("launch.sort", sortOrder),
+		attribute.Int("launch.count", len(launches)),
+	))
 	slices.SortFunc(launches, func(a, b models.Launch) int {
 		if sortOrder == "asc" {
 			return a.DateUTC.Compare(b.DateUTC)
 		}
 		return -a.DateUTC.Compare(b.DateUTC)
 	})
+	span.End()
 
 	return launches, nil
-}
\ No newline at end of file
+}
+
+func (s *baseLaunchService) GetByID(ctx context.Context, id string) (*models.Launch, error) {
+	launch, err := s.client.GetLaunch(ctx, id)
+	if errors.Is(err, clients.ErrNotFound) {
+		return nil, &LaunchNotFoundError{Lookup: "id " + id, cause: err}
+	}
+	return launch, err
+}
+
+func (s *baseLaunchService) GetByFlightNumber(ctx context.Context, flightNumber int) (*models.Launch, error) {
+	page, err := s.client.QueryLaunches(ctx, models.Query{
+		Query:   map[string]any{"flight_number": flightNumber},
+		Options: models.QueryOptions{Limit: 1},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(page.Docs) == 0 {
+		return nil, &LaunchNotFoundError{Lookup: fmt.Sprintf("flight number %d", flightNumber)}
+	}
+	return &page.Docs[0], nil
+}
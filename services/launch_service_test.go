@@ -7,20 +7,22 @@ import (
 	"testing"
 	"time"
 
+	"spacex-tracker/clients"
 	"spacex-tracker/models"
 )
 
-// type SpaceXClient interface {
-// 	GetNext(ctx context.Context) (*models.Launch, error)
-// 	GetLatest(ctx context.Context) (*models.Launch, error)
-// 	GetUpcoming(ctx context.Context) ([]models.Launch, error)
-// 	GetPast(ctx context.Context) ([]models.Launch, error)
-// }
+// MockSpaceXClient stubs the launch endpoints used by the service layer.
+// The embedded interface is left nil so any other call panics.
 type MockSpaceXClient struct {
+	clients.SpaceXClient
+
 	GetNextFunc     func(ctx context.Context) (*models.Launch, error)
 	GetLatestFunc   func(ctx context.Context) (*models.Launch, error)
 	GetUpcomingFunc func(ctx context.Context) ([]models.Launch, error)
 	GetPastFunc     func(ctx context.Context) ([]models.Launch, error)
+
+	GetLaunchFunc     func(ctx context.Context, id string) (*models.Launch, error)
+	QueryLaunchesFunc func(ctx context.Context, query models.Query) (*models.Page[models.Launch], error)
 }
 
 func (m *MockSpaceXClient) GetNext(ctx context.Context) (*models.Launch, error) {
@@ -38,6 +40,14 @@ func (m *MockSpaceXClient) GetUpcoming(ctx context.Context) ([]models.Launch, er
 func (m *MockSpaceXClient) GetPast(ctx context.Context) ([]models.Launch, error) {
 	return m.GetPastFunc(ctx)
 }
+
+func (m *MockSpaceXClient) GetLaunch(ctx context.Context, id string) (*models.Launch, error) {
+	return m.GetLaunchFunc(ctx, id)
+}
+
+func (m *MockSpaceXClient) QueryLaunches(ctx context.Context, query models.Query) (*models.Page[models.Launch], error) {
+	return m.QueryLaunchesFunc(ctx, query)
+}
 func TestGetNext_Success(t *testing.T) {
 	expected := &models.Launch{
 		Id:   "1",
@@ -130,7 +140,7 @@ func TestGetUpcoming_Success(t *testing.T) {
 
 	service := NewBaseLaunchService(mock)
 
-	result, err := service.GetUpcoming(context.Background())
+	result, err := service.GetUpcoming(context.Background(), LaunchFilter{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -149,7 +159,7 @@ func TestGetUpcoming_Error(t *testing.T) {
 
 	service := NewBaseLaunchService(mock)
 
-	_, err := service.GetUpcoming(context.Background())
+	_, err := service.GetUpcoming(context.Background(), LaunchFilter{})
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -172,7 +182,7 @@ func TestGetPast_SortDesc(t *testing.T) {
 
 	service := NewBaseLaunchService(mock)
 
-	result, err := service.GetPast(context.Background(), "desc")
+	result, err := service.GetPast(context.Background(), "desc", LaunchFilter{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -204,7 +214,7 @@ func TestGetPast_SortAsc(t *testing.T) {
 
 	service := NewBaseLaunchService(mock)
 
-	result, err := service.GetPast(context.Background(), "asc")
+	result, err := service.GetPast(context.Background(), "asc", LaunchFilter{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -228,8 +238,66 @@ func TestGetPast_Error(t *testing.T) {
 
 	service := NewBaseLaunchService(mock)
 
-	_, err := service.GetPast(context.Background(), "desc")
+	_, err := service.GetPast(context.Background(), "desc", LaunchFilter{})
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
-}
\ No newline at end of file
+}
+
+func TestGetByID_NotFound(t *testing.T) {
+	mockClient := &MockSpaceXClient{
+		GetLaunchFunc: func(ctx context.Context, id string) (*models.Launch, error) {
+			return nil, &clients.UpstreamStatusError{StatusCode: 404, URL: "/launches/" + id}
+		},
+	}
+
+	service := NewBaseLaunchService(mockClient)
+
+	_, err := service.GetByID(context.Background(), "5eb87cd9ffd86e000604b32a")
+
+	var notFound *LaunchNotFoundError
+	if !errors.As(err, &notFound) || notFound.Lookup != "id 5eb87cd9ffd86e000604b32a" {
+		t.Fatalf("expected a LaunchNotFoundError, got %v", err)
+	}
+
+	var statusErr *clients.UpstreamStatusError
+	if !errors.Is(err, clients.ErrNotFound) || !errors.As(err, &statusErr) {
+		t.Fatalf("expected the upstream 404 to stay reachable, got %v", err)
+	}
+}
+
+func TestGetByFlightNumber_QueriesUpstream(t *testing.T) {
+	var query models.Query
+	mockClient := &MockSpaceXClient{
+		QueryLaunchesFunc: func(ctx context.Context, q models.Query) (*models.Page[models.Launch], error) {
+			query = q
+			return &models.Page[models.Launch]{Docs: []models.Launch{{Name: "FalconSat", FlightNumber: 1}}}, nil
+		},
+	}
+
+	service := NewBaseLaunchService(mockClient)
+
+	launch, err := service.GetByFlightNumber(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if launch.Name != "FalconSat" || query.Query["flight_number"] != 1 || query.Options.Limit != 1 {
+		t.Fatalf("unexpected launch %+v for query %+v", launch, query)
+	}
+}
+
+func TestGetByFlightNumber_NotFound(t *testing.T) {
+	mockClient := &MockSpaceXClient{
+		QueryLaunchesFunc: func(ctx context.Context, q models.Query) (*models.Page[models.Launch], error) {
+			return &models.Page[models.Launch]{}, nil
+		},
+	}
+
+	service := NewBaseLaunchService(mockClient)
+
+	_, err := service.GetByFlightNumber(context.Background(), 9999)
+	if !errors.Is(err, clients.ErrNotFound) || err.Error() != "launch not found: flight number 9999" {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"spacex-tracker/clients"
+)
+
+const (
+	failureStatus    = "status"
+	failureTimeout   = "timeout"
+	failureMalformed = "malformed"
+	failureNotFound  = "not_found"
+)
+
+// cachedFailure is the stored form of an upstream failure. It keeps enough
+// to rebuild an error that matches the original with errors.Is and errors.As.
+type cachedFailure struct {
+	Kind       string `json:"kind"`
+	StatusCode int    `json:"status_code,omitempty"`
+	URL        string `json:"url,omitempty"`
+	Body       string `json:"body,omitempty"`
+	Message    string `json:"message,omitempty"`
+}
+
+// newCachedFailure returns the cacheable form of err. Only failures that say
+// something about upstream are cached: cancellations and errors raised by
+// this service (an open circuit, say) are not.
+func newCachedFailure(err error) (*cachedFailure, bool) {
+	if errors.Is(err, context.Canceled) {
+		return nil, false
+	}
+
+	var notFound *LaunchNotFoundError
+	var statusErr *clients.UpstreamStatusError
+	switch {
+	case errors.As(err, &notFound):
+		return &cachedFailure{Kind: failureNotFound, Message: notFound.Lookup}, true
+	case errors.As(err, &statusErr):
+		return &cachedFailure{
+			Kind:       failureStatus,
+			StatusCode: statusErr.StatusCode,
+			URL:        statusErr.URL,
+			Body:       statusErr.Body,
+		}, true
+	case errors.Is(err, clients.ErrTimeout):
+		return &cachedFailure{Kind: failureTimeout, Message: err.Error()}, true
+	case errors.Is(err, clients.ErrMalformedPayload):
+		return &cachedFailure{Kind: failureMalformed, Message: err.Error()}, true
+	default:
+		return nil, false
+	}
+}
+
+// err rebuilds the error the failure was created from.
+func (f *cachedFailure) err() error {
+	switch f.Kind {
+	case failureStatus:
+		return &clients.UpstreamStatusError{StatusCode: f.StatusCode, URL: f.URL, Body: f.Body}
+	case failureTimeout:
+		return &replayedError{sentinel: clients.ErrTimeout, message: f.Message}
+	case failureMalformed:
+		return &replayedError{sentinel: clients.ErrMalformedPayload, message: f.Message}
+	case failureNotFound:
+		return &LaunchNotFoundError{Lookup: f.Message}
+	default:
+		return errors.New(f.Message)
+	}
+}
+
+// replayedError carries the original message of a cached failure while
+// still matching its sentinel.
+type replayedError struct {
+	sentinel error
+	message  string
+}
+
+func (e *replayedError) Error() string { return e.message }
+
+func (e *replayedError) Unwrap() error { return e.sentinel }
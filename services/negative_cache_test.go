@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"spacex-tracker/clients"
+	"spacex-tracker/models"
+	"spacex-tracker/services/cache"
+)
+
+func newNegativeCachingService(t *testing.T) *cachedLaunchService {
+	t.Helper()
+	return NewCachedLaunchService(nil, cache.NewMemoryCache(10), time.Minute, WithNegativeTTL(time.Minute)).(*cachedLaunchService)
+}
+
+func TestNegativeCache_ReplaysUpstreamStatus(t *testing.T) {
+	svc := newNegativeCachingService(t)
+
+	calls := 0
+	notFound := func(ctx context.Context) (string, error) {
+		calls++
+		return "", &clients.UpstreamStatusError{StatusCode: 404, URL: "https://api/launches/next", Body: "Not Found"}
+	}
+
+	if _, err := getOrSet(context.Background(), svc, "key", fixedTTL[string](time.Minute), notFound); !errors.Is(err, clients.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	ctx, info := WithCacheInfo(context.Background())
+	_, err := getOrSet(ctx, svc, "key", fixedTTL[string](time.Minute), notFound)
+
+	var statusErr *clients.UpstreamStatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != 404 || statusErr.Body != "Not Found" {
+		t.Fatalf("expected the cached 404, got %v", err)
+	}
+	if !errors.Is(err, clients.ErrNotFound) {
+		t.Fatalf("expected the cached error to match ErrNotFound, got %v", err)
+	}
+	if calls != 1 || info.Status != CacheNegative {
+		t.Fatalf("expected one upstream call and a NEGATIVE status, got %d (%s)", calls, info.Status)
+	}
+}
+
+func TestNegativeCache_ReplaysTimeouts(t *testing.T) {
+	svc := newNegativeCachingService(t)
+	timeout := func(ctx context.Context) (string, error) {
+		return "", fmt.Errorf("%w: %w", clients.ErrTimeout, context.DeadlineExceeded)
+	}
+
+	getOrSet(context.Background(), svc, "key", fixedTTL[string](time.Minute), timeout)
+	_, err := getOrSet(context.Background(), svc, "key", fixedTTL[string](time.Minute), func(ctx context.Context) (string, error) {
+		t.Fatal("fetch should not be called while the failure is cached")
+		return "", nil
+	})
+
+	if !errors.Is(err, clients.ErrTimeout) || err.Error() != "upstream request timed out: context deadline exceeded" {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestNegativeCache_ExpiresAfterTTL(t *testing.T) {
+	svc := newNegativeCachingService(t)
+	now := time.Now()
+	svc.now = func() time.Time { return now }
+
+	getOrSet(context.Background(), svc, "key", fixedTTL[string](time.Minute), func(ctx context.Context) (string, error) {
+		return "", clients.ErrMalformedPayload
+	})
+
+	now = now.Add(2 * time.Minute)
+	result, err := getOrSet(context.Background(), svc, "key", fixedTTL[string](time.Minute), func(ctx context.Context) (string, error) {
+		return "recovered", nil
+	})
+	if err != nil || result != "recovered" {
+		t.Fatalf("expected a refetch once the negative entry expired, got %q, %v", result, err)
+	}
+}
+
+func TestNegativeCache_SkipsUncacheableErrors(t *testing.T) {
+	for _, failure := range []error{context.Canceled, clients.ErrCircuitOpen, errors.New("boom")} {
+		svc := newNegativeCachingService(t)
+
+		calls := 0
+		fetch := func(ctx context.Context) (string, error) {
+			calls++
+			return "", failure
+		}
+
+		getOrSet(context.Background(), svc, "key", fixedTTL[string](time.Minute), fetch)
+		getOrSet(context.Background(), svc, "key", fixedTTL[string](time.Minute), fetch)
+
+		if calls != 2 {
+			t.Fatalf("%v: expected the failure not to be cached, got %d calls", failure, calls)
+		}
+	}
+}
+
+func TestNegativeCache_DisabledByDefault(t *testing.T) {
+	mc := &mockCache{getErr: cache.ErrMiss}
+	svc := newTestCachedService(mc)
+
+	getOrSet(context.Background(), svc, "key", fixedTTL[string](time.Minute), func(ctx context.Context) (string, error) {
+		return "", clients.ErrTimeout
+	})
+
+	if mc.wasSet() {
+		t.Fatal("failures must not be cached without a negative TTL")
+	}
+}
+
+func TestNegativeCache_PrefersStaleEntries(t *testing.T) {
+	mc := &mockCache{getData: encodeEntry(t, "old", time.Now().Add(-time.Second))}
+	svc := NewCachedLaunchService(nil, mc, time.Minute, WithStaleTTL(time.Hour), WithNegativeTTL(time.Minute)).(*cachedLaunchService)
+
+	result, err := getOrSet(context.Background(), svc, "key", fixedTTL[string](time.Minute), func(ctx context.Context) (string, error) {
+		return "", clients.ErrTimeout
+	})
+	if err != nil || result != "old" {
+		t.Fatalf("expected stale value, got %q, %v", result, err)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	if mc.wasSet() {
+		t.Fatal("a failed refresh must not replace a stale entry with a negative one")
+	}
+}
+
+func TestNegativeCache_ReplaysLaunchNotFound(t *testing.T) {
+	calls := 0
+	client := &MockSpaceXClient{
+		QueryLaunchesFunc: func(ctx context.Context, q models.Query) (*models.Page[models.Launch], error) {
+			calls++
+			return &models.Page[models.Launch]{}, nil
+		},
+	}
+	svc := NewCachedLaunchService(NewBaseLaunchService(client), cache.NewMemoryCache(10), time.Minute, WithNegativeTTL(time.Minute))
+
+	svc.GetByFlightNumber(context.Background(), 9999)
+	_, err := svc.GetByFlightNumber(context.Background(), 9999)
+
+	var notFound *LaunchNotFoundError
+	if !errors.As(err, &notFound) || notFound.Lookup != "flight number 9999" {
+		t.Fatalf("expected a replayed LaunchNotFoundError, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected one upstream call, got %d", calls)
+	}
+}
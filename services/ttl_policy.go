@@ -0,0 +1,102 @@
+package services
+
+import (
+	"time"
+
+	"spacex-tracker/models"
+)
+
+// TTLPolicy decides how long each endpoint's response stays fresh in the cache.
+// With Adaptive set, next/upcoming entries expire sooner as the next launch
+// approaches, since its time and status change often around liftoff.
+type TTLPolicy struct {
+	Next     time.Duration
+	Latest   time.Duration
+	Upcoming time.Duration
+	Past     time.Duration
+	Adaptive bool
+
+	now func() time.Time
+}
+
+// UniformTTL returns a policy that uses ttl for every endpoint.
+func UniformTTL(ttl time.Duration) TTLPolicy {
+	return TTLPolicy{Next: ttl, Latest: ttl, Upcoming: ttl, Past: ttl}
+}
+
+const (
+	imminentLaunchWindow = time.Hour
+	imminentLaunchTTL    = 10 * time.Second
+	nearLaunchWindow     = 24 * time.Hour
+	nearLaunchTTL        = time.Minute
+	overdueLaunchWindow  = 6 * time.Hour
+)
+
+func (p TTLPolicy) ForNext(launch *models.Launch) time.Duration {
+	return p.nearLaunch(p.Next, launch)
+}
+
+func (p TTLPolicy) ForLatest(*models.Launch) time.Duration {
+	return p.Latest
+}
+
+// ForUpcoming uses the shortest TTL any launch in the list calls for.
+func (p TTLPolicy) ForUpcoming(launches []models.Launch) time.Duration {
+	ttl := p.Upcoming
+	for i := range launches {
+		ttl = min(ttl, p.nearLaunch(p.Upcoming, &launches[i]))
+	}
+	return ttl
+}
+
+func (p TTLPolicy) ForPast([]models.Launch) time.Duration {
+	return p.Past
+}
+
+// ForLaunch caches an upcoming launch like the upcoming list and a past one
+// like the past list, which rarely changes.
+func (p TTLPolicy) ForLaunch(launch *models.Launch) time.Duration {
+	if launch == nil || !launch.Upcoming {
+		return p.Past
+	}
+	return p.nearLaunch(p.Upcoming, launch)
+}
+
+// nearLaunch shortens base when launch is due within a day. A launch still
+// reported as upcoming up to overdueLaunchWindow past its date is treated as
+// imminent: it is either in progress or about to be rescheduled. Launches
+// overdue for longer, or whose date is only a placeholder, keep base.
+func (p TTLPolicy) nearLaunch(base time.Duration, launch *models.Launch) time.Duration {
+	if !p.Adaptive || launch == nil || launch.DateUTC.IsZero() || tentative(launch) {
+		return base
+	}
+
+	now := time.Now
+	if p.now != nil {
+		now = p.now
+	}
+
+	switch until := launch.DateUTC.Sub(now()); {
+	case until < -overdueLaunchWindow:
+		return base
+	case until <= imminentLaunchWindow:
+		return min(base, imminentLaunchTTL)
+	case until <= nearLaunchWindow:
+		return min(base, nearLaunchTTL)
+	default:
+		return base
+	}
+}
+
+// tentative reports whether launch's date is a placeholder: SpaceX marks it
+// TBD or only knows the month, quarter, half or year.
+func tentative(launch *models.Launch) bool {
+	if launch.TBD {
+		return true
+	}
+	switch launch.DatePrecision {
+	case "month", "quarter", "half", "year":
+		return true
+	}
+	return false
+}
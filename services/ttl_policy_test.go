@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"spacex-tracker/models"
+)
+
+func newTestTTLPolicy(now time.Time) TTLPolicy {
+	return TTLPolicy{
+		Next:     5 * time.Minute,
+		Latest:   5 * time.Minute,
+		Upcoming: 5 * time.Minute,
+		Past:     time.Hour,
+		Adaptive: true,
+		now:      func() time.Time { return now },
+	}
+}
+
+func TestTTLPolicy_ForNextShrinksNearLiftoff(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	policy := newTestTTLPolicy(now)
+
+	cases := []struct {
+		name     string
+		launchAt time.Time
+		expected time.Duration
+	}{
+		{"next week", now.Add(7 * 24 * time.Hour), 5 * time.Minute},
+		{"tomorrow", now.Add(12 * time.Hour), time.Minute},
+		{"T-30min", now.Add(30 * time.Minute), 10 * time.Second},
+		{"overdue", now.Add(-10 * time.Minute), 10 * time.Second},
+		{"days overdue", now.Add(-3 * 24 * time.Hour), 5 * time.Minute},
+	}
+
+	for _, tc := range cases {
+		got := policy.ForNext(&models.Launch{DateUTC: tc.launchAt})
+		if got != tc.expected {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, got)
+		}
+	}
+}
+
+func TestTTLPolicy_ForUpcomingUsesEarliestLaunch(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	policy := newTestTTLPolicy(now)
+
+	launches := []models.Launch{
+		{DateUTC: now.Add(30 * 24 * time.Hour)},
+		{DateUTC: now.Add(20 * time.Minute)},
+	}
+
+	if got := policy.ForUpcoming(launches); got != 10*time.Second {
+		t.Fatalf("expected 10s, got %v", got)
+	}
+}
+
+func TestTTLPolicy_IgnoresPlaceholderDates(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	policy := newTestTTLPolicy(now)
+
+	cases := []struct {
+		name   string
+		launch models.Launch
+	}{
+		{"tbd", models.Launch{DateUTC: now.Add(20 * time.Minute), TBD: true, DatePrecision: "hour"}},
+		{"month precision", models.Launch{DateUTC: now.Add(-time.Hour), DatePrecision: "month"}},
+		{"quarter precision", models.Launch{DateUTC: now.Add(20 * time.Minute), DatePrecision: "quarter"}},
+		{"half precision", models.Launch{DateUTC: now.Add(12 * time.Hour), DatePrecision: "half"}},
+		{"year precision", models.Launch{DateUTC: now, DatePrecision: "year"}},
+	}
+
+	for _, tc := range cases {
+		if got := policy.ForNext(&tc.launch); got != 5*time.Minute {
+			t.Errorf("%s: expected the configured TTL, got %v", tc.name, got)
+		}
+	}
+
+	dated := models.Launch{DateUTC: now.Add(20 * time.Minute), DatePrecision: "hour"}
+	if got := policy.ForNext(&dated); got != imminentLaunchTTL {
+		t.Fatalf("expected an hour-precision launch to count, got %v", got)
+	}
+}
+
+func TestTTLPolicy_ForUpcomingSkipsPlaceholdersAndStaleDates(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	policy := newTestTTLPolicy(now)
+
+	launches := []models.Launch{
+		{DateUTC: now.Add(-5 * 24 * time.Hour), DatePrecision: "hour"},
+		{DateUTC: now, TBD: true},
+		{DateUTC: now.Add(-time.Hour), DatePrecision: "month"},
+		{DateUTC: now.Add(12 * time.Hour), DatePrecision: "hour"},
+	}
+
+	if got := policy.ForUpcoming(launches); got != nearLaunchTTL {
+		t.Fatalf("expected the TTL of the first real launch date, got %v", got)
+	}
+}
+
+func TestTTLPolicy_ForLaunch(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	policy := newTestTTLPolicy(now)
+
+	if got := policy.ForLaunch(&models.Launch{Upcoming: false}); got != time.Hour {
+		t.Fatalf("expected the past TTL for a past launch, got %s", got)
+	}
+	if got := policy.ForLaunch(&models.Launch{Upcoming: true, DateUTC: now.Add(72 * time.Hour)}); got != 5*time.Minute {
+		t.Fatalf("expected the upcoming TTL for a distant launch, got %s", got)
+	}
+	if got := policy.ForLaunch(&models.Launch{Upcoming: true, DateUTC: now.Add(30 * time.Minute)}); got != imminentLaunchTTL {
+		t.Fatalf("expected the imminent TTL near liftoff, got %s", got)
+	}
+}
+
+func TestTTLPolicy_NotAdaptive(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	policy := newTestTTLPolicy(now)
+	policy.Adaptive = false
+
+	if got := policy.ForNext(&models.Launch{DateUTC: now.Add(time.Minute)}); got != 5*time.Minute {
+		t.Fatalf("expected configured TTL, got %v", got)
+	}
+
+	if got := policy.ForPast(nil); got != time.Hour {
+		t.Fatalf("expected past TTL, got %v", got)
+	}
+}
+
+type nextLaunchService struct {
+	LaunchService
+	next *models.Launch
+}
+
+func (s *nextLaunchService) GetNext(ctx context.Context) (*models.Launch, error) {
+	return s.next, nil
+}
+
+func TestCachedGetNext_UsesTTLPolicy(t *testing.T) {
+	mc := &mockCache{getErr: errors.New("miss")}
+	inner := &nextLaunchService{next: &models.Launch{DateUTC: time.Now().Add(10 * time.Minute)}}
+
+	policy := UniformTTL(5 * time.Minute)
+	policy.Adaptive = true
+
+	svc := NewCachedLaunchService(inner, mc, time.Minute, WithTTLPolicy(policy), WithStaleTTL(time.Minute))
+
+	if _, err := svc.GetNext(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if mc.setTTL != 10*time.Second+time.Minute {
+		t.Fatalf("expected imminent TTL plus stale window, got %v", mc.setTTL)
+	}
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"spacex-tracker/logging"
+	"spacex-tracker/services/cache"
+)
+
+const (
+	warmerLockKey = "spacex-tracker:lock:warmer"
+
+	// warmerLead is how long before an entry goes stale the warmer refreshes
+	// it, so requests keep finding a fresh entry.
+	warmerLead = 2 * time.Second
+
+	// minWarmerDelay keeps the warmer from spinning when an entry's TTL is
+	// shorter than warmerLead or another replica holds its lock.
+	minWarmerDelay = time.Second
+)
+
+// WarmedEndpoints are the endpoints kept warm by the Warmer.
+var WarmedEndpoints = []string{"next", "latest", "upcoming", "past"}
+
+// Warmable is a Refresher that can also report how long each endpoint's
+// cached entry stays fresh.
+type Warmable interface {
+	Refresher
+	FreshUntil(ctx context.Context, endpoint string) (time.Time, error)
+}
+
+// Warmer refreshes each cached endpoint shortly before its entry goes stale,
+// so that user requests don't have to wait on upstream after it expires.
+// Endpoints are refreshed on their own TTLs: a long-lived past list isn't
+// re-fetched just because next is about to expire. With a Locker, only the
+// replica that takes an endpoint's lock refreshes it.
+type Warmer struct {
+	refresher Warmable
+	locker    cache.Locker
+	interval  time.Duration
+	jitter    float64
+	now       func() time.Time
+
+	// retryAt holds back endpoints whose last refresh failed or was left to
+	// another replica.
+	retryAt map[string]time.Time
+}
+
+// NewWarmer returns a Warmer that checks its endpoints at least once per
+// interval. interval also bounds how long an endpoint's lock is held and how
+// long a failed refresh waits before it is retried.
+func NewWarmer(refresher Warmable, locker cache.Locker, interval time.Duration, jitter float64) *Warmer {
+	return &Warmer{
+		refresher: refresher,
+		locker:    locker,
+		interval:  interval,
+		jitter:    min(max(jitter, 0), 1),
+		now:       time.Now,
+		retryAt:   make(map[string]time.Time),
+	}
+}
+
+// Run warms the cache immediately and then whenever an endpoint is due,
+// until ctx is done.
+func (w *Warmer) Run(ctx context.Context) {
+	for {
+		wait := w.warm(ctx)
+
+		timer := time.NewTimer(w.jittered(wait))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// jittered returns d randomised by ±jitter so replicas drift apart.
+func (w *Warmer) jittered(d time.Duration) time.Duration {
+	spread := (rand.Float64()*2 - 1) * w.jitter
+	return time.Duration(float64(d) * (1 + spread))
+}
+
+// warm refreshes the endpoints that are due and returns how long to wait
+// before the next one is.
+func (w *Warmer) warm(ctx context.Context) time.Duration {
+	now := w.now()
+	next := now.Add(w.interval)
+
+	for _, endpoint := range WarmedEndpoints {
+		if ctx.Err() != nil {
+			break
+		}
+
+		due := w.due(ctx, endpoint)
+		if due.After(now) {
+			next = earliest(next, due)
+			continue
+		}
+
+		retry, err := w.refresh(ctx, endpoint)
+		if err != nil {
+			logging.FromContext(ctx).Warn("cache warmer failed to refresh", "endpoint", endpoint, "error", err)
+		}
+		if !retry.IsZero() {
+			w.retryAt[endpoint] = retry
+			next = earliest(next, retry)
+			continue
+		}
+		delete(w.retryAt, endpoint)
+		next = earliest(next, w.due(ctx, endpoint))
+	}
+
+	return max(next.Sub(w.now()), min(minWarmerDelay, w.interval))
+}
+
+// due returns when endpoint should next be refreshed. An endpoint with
+// nothing cached is due immediately. Once a hold-back from retryAt has
+// passed, the entry is checked again: another replica may have refreshed it.
+func (w *Warmer) due(ctx context.Context, endpoint string) time.Time {
+	if retry, ok := w.retryAt[endpoint]; ok {
+		if retry.After(w.now()) {
+			return retry
+		}
+		delete(w.retryAt, endpoint)
+	}
+
+	freshUntil, err := w.refresher.FreshUntil(ctx, endpoint)
+	if err != nil {
+		logging.FromContext(ctx).Warn("cache warmer failed to read entry", "endpoint", endpoint, "error", err)
+		return time.Time{}
+	}
+	if freshUntil.IsZero() {
+		return freshUntil
+	}
+	return freshUntil.Add(-warmerLead)
+}
+
+// refresh refreshes endpoint under its lock, unless the entry turns out to
+// have been refreshed by the replica that held the lock before. It returns
+// when to try again if the endpoint should be held back: after a failure, or
+// while another replica holds the lock.
+func (w *Warmer) refresh(ctx context.Context, endpoint string) (time.Time, error) {
+	if w.locker != nil {
+		unlock, err := w.locker.Lock(ctx, warmerLockKey+":"+endpoint, w.interval)
+		if errors.Is(err, cache.ErrLocked) {
+			return w.now().Add(min(minWarmerDelay, w.interval)), nil
+		}
+		if err != nil {
+			return w.now().Add(w.interval), err
+		}
+		defer func() {
+			if err := unlock(context.WithoutCancel(ctx)); err != nil {
+				logging.FromContext(ctx).Warn("cache warmer failed to release lock", "endpoint", endpoint, "error", err)
+			}
+		}()
+
+		if w.due(ctx, endpoint).After(w.now()) {
+			return time.Time{}, nil
+		}
+	}
+
+	if err := w.refresher.Refresh(ctx, endpoint); err != nil {
+		return w.now().Add(w.interval), err
+	}
+	return time.Time{}, nil
+}
+
+// earliest returns the earlier of a and b, ignoring a zero b.
+func earliest(a, b time.Time) time.Time {
+	if b.IsZero() || a.Before(b) {
+		return a
+	}
+	return b
+}
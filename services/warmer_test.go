@@ -0,0 +1,229 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"spacex-tracker/services/cache"
+)
+
+// recordingRefresher records refreshes. Endpoints without an entry in fresh
+// are reported as uncached; a refresh caches the endpoint for its ttl.
+type recordingRefresher struct {
+	mu        sync.Mutex
+	refreshed []string
+	fresh     map[string]time.Time
+	ttls      map[string]time.Duration
+	now       func() time.Time
+	err       error
+}
+
+func (r *recordingRefresher) Refresh(ctx context.Context, endpoint string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.refreshed = append(r.refreshed, endpoint)
+	if r.err != nil {
+		return r.err
+	}
+	if ttl, ok := r.ttls[endpoint]; ok {
+		r.fresh[endpoint] = r.now().Add(ttl)
+	}
+	return nil
+}
+
+func (r *recordingRefresher) FreshUntil(ctx context.Context, endpoint string) (time.Time, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.fresh[endpoint], nil
+}
+
+func (r *recordingRefresher) endpoints() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.refreshed)
+}
+
+type heldLocker struct{}
+
+func (heldLocker) Lock(ctx context.Context, key string, ttl time.Duration) (func(context.Context) error, error) {
+	return nil, cache.ErrLocked
+}
+
+// handoffLocker is held by another replica until release is called. onLock
+// runs when the lock is taken.
+type handoffLocker struct {
+	held   bool
+	onLock func()
+}
+
+func (l *handoffLocker) Lock(ctx context.Context, key string, ttl time.Duration) (func(context.Context) error, error) {
+	if l.held {
+		return nil, cache.ErrLocked
+	}
+	if l.onLock != nil {
+		l.onLock()
+	}
+	return func(context.Context) error { return nil }, nil
+}
+
+func TestWarmer_RefreshesEveryEndpoint(t *testing.T) {
+	refresher := &recordingRefresher{}
+	warmer := NewWarmer(refresher, nil, time.Hour, 0)
+
+	warmer.warm(context.Background())
+
+	if !slices.Equal(refresher.endpoints(), WarmedEndpoints) {
+		t.Fatalf("unexpected endpoints refreshed: %v", refresher.endpoints())
+	}
+}
+
+func TestWarmer_SkipsWhenLockIsHeld(t *testing.T) {
+	refresher := &recordingRefresher{}
+	warmer := NewWarmer(refresher, heldLocker{}, time.Hour, 0)
+
+	warmer.warm(context.Background())
+
+	if len(refresher.endpoints()) != 0 {
+		t.Fatalf("expected no refresh, got %v", refresher.endpoints())
+	}
+}
+
+func TestWarmer_RunStopsOnCancel(t *testing.T) {
+	refresher := &recordingRefresher{}
+	warmer := NewWarmer(refresher, nil, 5*time.Millisecond, 0.5)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		warmer.Run(ctx)
+		close(done)
+	}()
+
+	time.Sleep(30 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+
+	if runs := len(refresher.endpoints()) / len(WarmedEndpoints); runs < 2 {
+		t.Fatalf("expected several runs, got %d", runs)
+	}
+}
+
+func TestWarmer_JitterStaysInBounds(t *testing.T) {
+	warmer := NewWarmer(nil, nil, time.Minute, 0.2)
+
+	for range 100 {
+		if d := warmer.jittered(time.Minute); d < 48*time.Second || d > 72*time.Second {
+			t.Fatalf("interval %v outside ±20%%", d)
+		}
+	}
+}
+
+func TestWarmer_RefreshesEachEndpointByItsOwnTTL(t *testing.T) {
+	clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := func() time.Time { return clock }
+	refresher := &recordingRefresher{
+		fresh: map[string]time.Time{
+			"next":   clock.Add(5 * time.Second),
+			"latest": clock.Add(time.Second),
+			"past":   clock.Add(time.Hour),
+		},
+		ttls: map[string]time.Duration{
+			"next":     10 * time.Second,
+			"latest":   10 * time.Second,
+			"upcoming": 30 * time.Second,
+			"past":     time.Hour,
+		},
+		now: now,
+	}
+	warmer := NewWarmer(refresher, nil, time.Minute, 0)
+	warmer.now = now
+
+	wait := warmer.warm(context.Background())
+
+	if got := refresher.endpoints(); !slices.Equal(got, []string{"latest", "upcoming"}) {
+		t.Fatalf("expected only the entries near expiry to be refreshed, got %v", got)
+	}
+	if want := 5*time.Second - warmerLead; wait != want {
+		t.Fatalf("expected to wake up %v later for next, got %v", want, wait)
+	}
+}
+
+func TestWarmer_BacksOffAfterFailure(t *testing.T) {
+	clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := func() time.Time { return clock }
+	refresher := &recordingRefresher{now: now, err: errors.New("upstream down")}
+	warmer := NewWarmer(refresher, nil, time.Minute, 0)
+	warmer.now = now
+
+	if wait := warmer.warm(context.Background()); wait != time.Minute {
+		t.Fatalf("expected to retry after the interval, got %v", wait)
+	}
+
+	clock = clock.Add(10 * time.Second)
+	warmer.warm(context.Background())
+	if n := len(refresher.endpoints()); n != len(WarmedEndpoints) {
+		t.Fatalf("expected failed endpoints to be held back, got %d refreshes", n)
+	}
+
+	clock = clock.Add(time.Minute)
+	warmer.warm(context.Background())
+	if n := len(refresher.endpoints()); n != 2*len(WarmedEndpoints) {
+		t.Fatalf("expected a retry after the interval, got %d refreshes", n)
+	}
+}
+
+func TestWarmer_SkipsEndpointsRefreshedByTheLockHolder(t *testing.T) {
+	clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := func() time.Time { return clock }
+	refresher := &recordingRefresher{fresh: map[string]time.Time{}, now: now}
+	locker := &handoffLocker{held: true}
+	warmer := NewWarmer(refresher, locker, time.Minute, 0)
+	warmer.now = now
+
+	if wait := warmer.warm(context.Background()); wait != minWarmerDelay {
+		t.Fatalf("expected to check again after %v, got %v", minWarmerDelay, wait)
+	}
+
+	// The other replica refreshes every endpoint, then releases its lock.
+	for _, endpoint := range WarmedEndpoints {
+		refresher.fresh[endpoint] = clock.Add(time.Hour)
+	}
+	locker.held = false
+	clock = clock.Add(minWarmerDelay)
+
+	warmer.warm(context.Background())
+	if got := refresher.endpoints(); len(got) != 0 {
+		t.Fatalf("expected fresh endpoints to be skipped, got %v", got)
+	}
+}
+
+func TestWarmer_RechecksEntryAfterTakingLock(t *testing.T) {
+	clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := func() time.Time { return clock }
+	refresher := &recordingRefresher{fresh: map[string]time.Time{}, now: now}
+	// Each endpoint is refreshed by another replica just before the lock is
+	// handed over.
+	locker := &handoffLocker{}
+	warmer := NewWarmer(refresher, locker, time.Minute, 0)
+	warmer.now = now
+	locker.onLock = func() {
+		for _, endpoint := range WarmedEndpoints {
+			refresher.fresh[endpoint] = clock.Add(time.Hour)
+		}
+	}
+
+	warmer.warm(context.Background())
+
+	if got := refresher.endpoints(); len(got) != 0 {
+		t.Fatalf("expected no refresh once the entry is fresh, got %v", got)
+	}
+}
@@ -0,0 +1,66 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"spacex-tracker/services/cache"
+)
+
+type tracedCache struct {
+	inner cache.Cache
+}
+
+// NewTracedCache wraps inner so that every cache operation gets a span
+// carrying the key or pattern, and for Get whether it hit.
+func NewTracedCache(inner cache.Cache) cache.Cache {
+	return &tracedCache{inner: inner}
+}
+
+func (c *tracedCache) Get(ctx context.Context, key string) ([]byte, error) {
+	ctx, s := tracer().Start(ctx, "cache.Get", trace.WithAttributes(attribute.String("cache.key", key)))
+	defer s.End()
+
+	data, err := c.inner.Get(ctx, key)
+	s.SetAttributes(attribute.Bool("cache.hit", err == nil))
+	// A miss is an expected outcome, not a failed span.
+	if err != nil && !errors.Is(err, cache.ErrMiss) {
+		s.RecordError(err)
+		s.SetStatus(codes.Error, err.Error())
+	}
+	return data, err
+}
+
+func (c *tracedCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	_, err := span(ctx, "cache.Set", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, c.inner.Set(ctx, key, value, ttl)
+	}, attribute.String("cache.key", key), attribute.Int("cache.value_bytes", len(value)), attribute.String("cache.ttl", ttl.String()))
+	return err
+}
+
+func (c *tracedCache) Delete(ctx context.Context, key string) error {
+	_, err := span(ctx, "cache.Delete", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, c.inner.Delete(ctx, key)
+	}, attribute.String("cache.key", key))
+	return err
+}
+
+func (c *tracedCache) DeleteMatching(ctx context.Context, pattern string) (int, error) {
+	return span(ctx, "cache.DeleteMatching", func(ctx context.Context) (int, error) {
+		return c.inner.DeleteMatching(ctx, pattern)
+	}, attribute.String("cache.pattern", pattern))
+}
+
+func (c *tracedCache) Keys(ctx context.Context, pattern string) ([]cache.KeyInfo, error) {
+	return span(ctx, "cache.Keys", func(ctx context.Context) ([]cache.KeyInfo, error) {
+		return c.inner.Keys(ctx, pattern)
+	}, attribute.String("cache.pattern", pattern))
+}
+
+func (c *tracedCache) Stats(ctx context.Context) (cache.Stats, error) {
+	return span(ctx, "cache.Stats", c.inner.Stats)
+}
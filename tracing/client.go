@@ -0,0 +1,124 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"spacex-tracker/clients"
+	"spacex-tracker/models"
+)
+
+// span runs call inside a new span named name, recording its error.
+func span[T any](ctx context.Context, name string, call func(context.Context) (T, error), attrs ...attribute.KeyValue) (T, error) {
+	ctx, s := tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+	defer s.End()
+
+	result, err := call(ctx)
+	if err != nil {
+		s.RecordError(err)
+		s.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+type tracedClient struct {
+	inner clients.SpaceXClient
+}
+
+// NewTracedClient wraps inner so that every SpaceXClient call gets a span.
+// The HTTP attempts it makes, with their URL and status, are child spans.
+func NewTracedClient(inner clients.SpaceXClient) clients.SpaceXClient {
+	return &tracedClient{inner: inner}
+}
+
+func (c *tracedClient) GetNext(ctx context.Context) (*models.Launch, error) {
+	return span(ctx, "SpaceXClient.GetNext", func(ctx context.Context) (*models.Launch, error) { return c.inner.GetNext(ctx) })
+}
+
+func (c *tracedClient) GetLatest(ctx context.Context) (*models.Launch, error) {
+	return span(ctx, "SpaceXClient.GetLatest", func(ctx context.Context) (*models.Launch, error) { return c.inner.GetLatest(ctx) })
+}
+
+func (c *tracedClient) GetUpcoming(ctx context.Context) ([]models.Launch, error) {
+	return span(ctx, "SpaceXClient.GetUpcoming", func(ctx context.Context) ([]models.Launch, error) { return c.inner.GetUpcoming(ctx) })
+}
+
+func (c *tracedClient) GetPast(ctx context.Context) ([]models.Launch, error) {
+	return span(ctx, "SpaceXClient.GetPast", func(ctx context.Context) ([]models.Launch, error) { return c.inner.GetPast(ctx) })
+}
+
+func (c *tracedClient) GetLaunch(ctx context.Context, id string) (*models.Launch, error) {
+	return span(ctx, "SpaceXClient.GetLaunch", func(ctx context.Context) (*models.Launch, error) { return c.inner.GetLaunch(ctx, id) }, attribute.String("spacex.id", id))
+}
+
+func (c *tracedClient) QueryLaunches(ctx context.Context, query models.Query) (*models.Page[models.Launch], error) {
+	return span(ctx, "SpaceXClient.QueryLaunches", func(ctx context.Context) (*models.Page[models.Launch], error) {
+		return c.inner.QueryLaunches(ctx, query)
+	})
+}
+
+func (c *tracedClient) GetRocket(ctx context.Context, id string) (*models.Rocket, error) {
+	return span(ctx, "SpaceXClient.GetRocket", func(ctx context.Context) (*models.Rocket, error) { return c.inner.GetRocket(ctx, id) }, attribute.String("spacex.id", id))
+}
+
+func (c *tracedClient) GetRockets(ctx context.Context) ([]models.Rocket, error) {
+	return span(ctx, "SpaceXClient.GetRockets", func(ctx context.Context) ([]models.Rocket, error) { return c.inner.GetRockets(ctx) })
+}
+
+func (c *tracedClient) GetLaunchpad(ctx context.Context, id string) (*models.Launchpad, error) {
+	return span(ctx, "SpaceXClient.GetLaunchpad", func(ctx context.Context) (*models.Launchpad, error) { return c.inner.GetLaunchpad(ctx, id) }, attribute.String("spacex.id", id))
+}
+
+func (c *tracedClient) GetLaunchpads(ctx context.Context) ([]models.Launchpad, error) {
+	return span(ctx, "SpaceXClient.GetLaunchpads", func(ctx context.Context) ([]models.Launchpad, error) { return c.inner.GetLaunchpads(ctx) })
+}
+
+func (c *tracedClient) GetLandpad(ctx context.Context, id string) (*models.Landpad, error) {
+	return span(ctx, "SpaceXClient.GetLandpad", func(ctx context.Context) (*models.Landpad, error) { return c.inner.GetLandpad(ctx, id) }, attribute.String("spacex.id", id))
+}
+
+func (c *tracedClient) GetLandpads(ctx context.Context) ([]models.Landpad, error) {
+	return span(ctx, "SpaceXClient.GetLandpads", func(ctx context.Context) ([]models.Landpad, error) { return c.inner.GetLandpads(ctx) })
+}
+
+func (c *tracedClient) GetPayload(ctx context.Context, id string) (*models.Payload, error) {
+	return span(ctx, "SpaceXClient.GetPayload", func(ctx context.Context) (*models.Payload, error) { return c.inner.GetPayload(ctx, id) }, attribute.String("spacex.id", id))
+}
+
+func (c *tracedClient) GetPayloads(ctx context.Context) ([]models.Payload, error) {
+	return span(ctx, "SpaceXClient.GetPayloads", func(ctx context.Context) ([]models.Payload, error) { return c.inner.GetPayloads(ctx) })
+}
+
+func (c *tracedClient) GetCore(ctx context.Context, id string) (*models.Core, error) {
+	return span(ctx, "SpaceXClient.GetCore", func(ctx context.Context) (*models.Core, error) { return c.inner.GetCore(ctx, id) }, attribute.String("spacex.id", id))
+}
+
+func (c *tracedClient) GetCores(ctx context.Context) ([]models.Core, error) {
+	return span(ctx, "SpaceXClient.GetCores", func(ctx context.Context) ([]models.Core, error) { return c.inner.GetCores(ctx) })
+}
+
+func (c *tracedClient) GetCapsule(ctx context.Context, id string) (*models.Capsule, error) {
+	return span(ctx, "SpaceXClient.GetCapsule", func(ctx context.Context) (*models.Capsule, error) { return c.inner.GetCapsule(ctx, id) }, attribute.String("spacex.id", id))
+}
+
+func (c *tracedClient) GetCapsules(ctx context.Context) ([]models.Capsule, error) {
+	return span(ctx, "SpaceXClient.GetCapsules", func(ctx context.Context) ([]models.Capsule, error) { return c.inner.GetCapsules(ctx) })
+}
+
+func (c *tracedClient) GetCrewMember(ctx context.Context, id string) (*models.CrewMember, error) {
+	return span(ctx, "SpaceXClient.GetCrewMember", func(ctx context.Context) (*models.CrewMember, error) { return c.inner.GetCrewMember(ctx, id) }, attribute.String("spacex.id", id))
+}
+
+func (c *tracedClient) GetCrew(ctx context.Context) ([]models.CrewMember, error) {
+	return span(ctx, "SpaceXClient.GetCrew", func(ctx context.Context) ([]models.CrewMember, error) { return c.inner.GetCrew(ctx) })
+}
+
+func (c *tracedClient) GetShip(ctx context.Context, id string) (*models.Ship, error) {
+	return span(ctx, "SpaceXClient.GetShip", func(ctx context.Context) (*models.Ship, error) { return c.inner.GetShip(ctx, id) }, attribute.String("spacex.id", id))
+}
+
+func (c *tracedClient) GetShips(ctx context.Context) ([]models.Ship, error) {
+	return span(ctx, "SpaceXClient.GetShips", func(ctx context.Context) ([]models.Ship, error) { return c.inner.GetShips(ctx) })
+}
@@ -0,0 +1,51 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"spacex-tracker/models"
+	"spacex-tracker/services"
+)
+
+type tracedService struct {
+	inner services.LaunchService
+}
+
+// NewTracedService wraps inner so that every LaunchService call gets a span.
+// The cached service annotates it with the cache key and status.
+func NewTracedService(inner services.LaunchService) services.LaunchService {
+	return &tracedService{inner: inner}
+}
+
+func (s *tracedService) GetNext(ctx context.Context) (*models.Launch, error) {
+	return span(ctx, "LaunchService.GetNext", s.inner.GetNext)
+}
+
+func (s *tracedService) GetLatest(ctx context.Context) (*models.Launch, error) {
+	return span(ctx, "LaunchService.GetLatest", s.inner.GetLatest)
+}
+
+func (s *tracedService) GetUpcoming(ctx context.Context, filter services.LaunchFilter) ([]models.Launch, error) {
+	return span(ctx, "LaunchService.GetUpcoming", func(ctx context.Context) ([]models.Launch, error) {
+		return s.inner.GetUpcoming(ctx, filter)
+	}, attribute.Bool("launch.filtered", !filter.IsZero()))
+}
+
+func (s *tracedService) GetPast(ctx context.Context, sortOrder string, filter services.LaunchFilter) ([]models.Launch, error) {
+	return span(ctx, "LaunchService.GetPast", func(ctx context.Context) ([]models.Launch, error) {
+		return s.inner.GetPast(ctx, sortOrder, filter)
+	}, attribute.String("launch.sort", sortOrder), attribute.Bool("launch.filtered", !filter.IsZero()))
+}
+
+func (s *tracedService) GetByID(ctx context.Context, id string) (*models.Launch, error) {
+	return span(ctx, "LaunchService.GetByID", func(ctx context.Context) (*models.Launch, error) {
+		return s.inner.GetByID(ctx, id)
+	}, attribute.String("launch.id", id))
+}
+
+func (s *tracedService) GetByFlightNumber(ctx context.Context, flightNumber int) (*models.Launch, error) {
+	return span(ctx, "LaunchService.GetByFlightNumber", func(ctx context.Context) (*models.Launch, error) {
+		return s.inner.GetByFlightNumber(ctx, flightNumber)
+	}, attribute.Int("launch.flight_number", flightNumber))
+}
@@ -0,0 +1,117 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"spacex-tracker/models"
+	"spacex-tracker/services"
+	"spacex-tracker/services/cache"
+)
+
+func setupRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+	return recorder
+}
+
+type nextService struct {
+	services.LaunchService
+}
+
+func (s *nextService) GetNext(ctx context.Context) (*models.Launch, error) {
+	return &models.Launch{Name: "Falcon 9"}, nil
+}
+
+func attr(span sdktrace.ReadOnlySpan, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestMiddleware_ContinuesIncomingTraceThroughServiceAndCache(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	recorder := setupRecorder(t)
+
+	svc := NewTracedService(services.NewCachedLaunchService(&nextService{}, NewTracedCache(cache.NewMemoryCache(10)), time.Minute))
+
+	r := gin.New()
+	r.Use(Middleware())
+	r.GET("/api/v1/launches/next", func(c *gin.Context) {
+		launch, _ := svc.GetNext(c.Request.Context())
+		c.JSON(http.StatusOK, launch)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/launches/next", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+		if got := span.SpanContext().TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+			t.Fatalf("span %q is not part of the incoming trace: %s", span.Name(), got)
+		}
+	}
+
+	server, ok := spans["GET /api/v1/launches/next"]
+	if !ok {
+		t.Fatalf("missing server span, got %v", spans)
+	}
+	if v, _ := attr(server, "http.response.status_code"); v.AsInt64() != 200 {
+		t.Fatalf("unexpected status attribute %v", v)
+	}
+
+	service := spans["LaunchService.GetNext"]
+	if service == nil || service.Parent().SpanID() != server.SpanContext().SpanID() {
+		t.Fatal("expected the service span to be a child of the server span")
+	}
+	if v, _ := attr(service, "cache.status"); v.AsString() != "MISS" {
+		t.Fatalf("unexpected cache.status %v", v)
+	}
+	if v, _ := attr(service, "cache.key"); v.AsString() != "launch:next" {
+		t.Fatalf("unexpected cache.key %v", v)
+	}
+
+	get := spans["cache.Get"]
+	if get == nil {
+		t.Fatal("missing cache.Get span")
+	}
+	if v, _ := attr(get, "cache.hit"); v.AsBool() {
+		t.Fatal("expected cache.hit to be false on a miss")
+	}
+	if get.Status().Code != 0 {
+		t.Fatalf("a cache miss must not mark the span as failed: %v", get.Status())
+	}
+	if _, ok := spans["cache.Set"]; !ok {
+		t.Fatal("missing cache.Set span")
+	}
+}
+
+func TestSetup_RejectsUnknownExporter(t *testing.T) {
+	if _, err := Setup(context.Background(), "jaeger"); err == nil {
+		t.Fatal("expected an error for an unknown exporter")
+	}
+
+	shutdown, err := Setup(context.Background(), ExporterNone)
+	if err != nil || shutdown(context.Background()) != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}